@@ -0,0 +1,124 @@
+package baseline
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"gantt-gen/calendar"
+	"gantt-gen/model"
+)
+
+const dateLayout = "2006-01-02"
+
+// Snapshot is a saved copy of a resolved schedule
+type Snapshot struct {
+	Project string           `json:"project"`
+	Tasks   map[string]Dates `json:"tasks"`
+}
+
+// Dates is a task's scheduled start and end in a snapshot
+type Dates struct {
+	Start time.Time
+	End   time.Time
+}
+
+type datesJSON struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+// MarshalJSON writes dates as YYYY-MM-DD so sidecar files stay readable in diffs
+func (d Dates) MarshalJSON() ([]byte, error) {
+	return json.Marshal(datesJSON{Start: d.Start.Format(dateLayout), End: d.End.Format(dateLayout)})
+}
+
+// UnmarshalJSON reads dates written by MarshalJSON
+func (d *Dates) UnmarshalJSON(data []byte) error {
+	var raw datesJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	start, err := time.Parse(dateLayout, raw.Start)
+	if err != nil {
+		return fmt.Errorf("invalid start date %q: %w", raw.Start, err)
+	}
+	end, err := time.Parse(dateLayout, raw.End)
+	if err != nil {
+		return fmt.Errorf("invalid end date %q: %w", raw.End, err)
+	}
+	d.Start, d.End = start, end
+	return nil
+}
+
+// New snapshots the calculated dates of every resolved task
+func New(project *model.Project) *Snapshot {
+	snap := &Snapshot{
+		Project: project.Name,
+		Tasks:   make(map[string]Dates),
+	}
+	for _, task := range project.Tasks {
+		if task.CalculatedStart == nil || task.CalculatedEnd == nil {
+			continue
+		}
+		snap.Tasks[task.Name] = Dates{Start: *task.CalculatedStart, End: *task.CalculatedEnd}
+	}
+	return snap
+}
+
+// Save writes a snapshot to path as indented JSON
+func Save(path string, snap *Snapshot) error {
+	data, err := json.MarshalIndent(snap, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// Load reads a snapshot written by Save
+func Load(path string) (*Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var snap Snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, fmt.Errorf("invalid baseline %s: %w", path, err)
+	}
+	return &snap, nil
+}
+
+// Variance is how far a task has moved since the baseline, in business days
+// on the task's calendar; positive values are slips
+type Variance struct {
+	Task   string
+	Start  int
+	Finish int
+}
+
+// TaskVariance compares a resolved task with its baseline dates; ok is false
+// when the task is unresolved or not in the baseline
+func (s *Snapshot) TaskVariance(project *model.Project, task *model.Task) (Variance, bool) {
+	dates, ok := s.Tasks[task.Name]
+	if !ok || task.CalculatedStart == nil || task.CalculatedEnd == nil {
+		return Variance{}, false
+	}
+	cal := project.CalendarFor(task)
+	return Variance{
+		Task:   task.Name,
+		Start:  calendar.BusinessDaysBetween(dates.Start, *task.CalculatedStart, cal),
+		Finish: calendar.BusinessDaysBetween(dates.End, *task.CalculatedEnd, cal),
+	}, true
+}
+
+// Compare returns the variance of every task that is in both the project and the baseline
+func (s *Snapshot) Compare(project *model.Project) []Variance {
+	var variances []Variance
+	for i := range project.Tasks {
+		if v, ok := s.TaskVariance(project, &project.Tasks[i]); ok {
+			variances = append(variances, v)
+		}
+	}
+	return variances
+}
@@ -0,0 +1,89 @@
+package baseline
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gantt-gen/model"
+)
+
+func date(day int) *time.Time {
+	t := time.Date(2024, 1, day, 0, 0, 0, 0, time.UTC)
+	return &t
+}
+
+func TestSaveLoad(t *testing.T) {
+	project := &model.Project{
+		Name: "Test",
+		Tasks: []model.Task{
+			{Name: "Design", CalculatedStart: date(1), CalculatedEnd: date(5)},
+			{Name: "Unresolved"},
+		},
+	}
+
+	path := filepath.Join(t.TempDir(), "plan.baseline.json")
+	if err := Save(path, New(project)); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	snap, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if snap.Project != "Test" || len(snap.Tasks) != 1 {
+		t.Fatalf("Load() = %+v, want Test with one task", snap)
+	}
+	got := snap.Tasks["Design"]
+	if !got.Start.Equal(*date(1)) || !got.End.Equal(*date(5)) {
+		t.Errorf("Design = %v - %v, want Jan 1 - Jan 5", got.Start, got.End)
+	}
+}
+
+func TestLoad_InvalidDate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bad.json")
+	if err := writeFile(path, `{"project": "Test", "tasks": {"Design": {"start": "soon", "end": "2024-01-05"}}}`); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Error("Load() expected error for invalid date")
+	}
+}
+
+func TestCompare(t *testing.T) {
+	snap := &Snapshot{
+		Tasks: map[string]Dates{
+			"Design": {Start: *date(1), End: *date(5)},
+			"Build":  {Start: *date(8), End: *date(12)},
+		},
+	}
+
+	// Design slipped from Friday Jan 5 to Tuesday Jan 9; Build was pulled in
+	project := &model.Project{
+		Tasks: []model.Task{
+			{Name: "Design", CalculatedStart: date(1), CalculatedEnd: date(9)},
+			{Name: "Build", CalculatedStart: date(5), CalculatedEnd: date(11)},
+			{Name: "New", CalculatedStart: date(1), CalculatedEnd: date(2)},
+		},
+	}
+
+	got := snap.Compare(project)
+	want := []Variance{
+		{Task: "Design", Start: 0, Finish: 2},
+		{Task: "Build", Start: -1, Finish: -1},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Compare() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Compare()[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func writeFile(path, content string) error {
+	return os.WriteFile(path, []byte(content), 0644)
+}
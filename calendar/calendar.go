@@ -26,6 +26,63 @@ func AddBusinessDays(start time.Time, days int, cal *model.Calendar) time.Time {
 	return current
 }
 
+// SubtractBusinessDays moves back the specified number of business days from end date.
+// The result lands on a business day and satisfies AddBusinessDays(result, days) == end
+// whenever end is itself a business day.
+func SubtractBusinessDays(end time.Time, days int, cal *model.Calendar) time.Time {
+	if cal == nil {
+		cal = DefaultCalendar()
+	}
+
+	current := end
+	remaining := days
+
+	for remaining > 0 {
+		if IsBusinessDay(current, cal) {
+			remaining--
+		}
+		current = current.AddDate(0, 0, -1)
+	}
+
+	// Don't start on a weekend or holiday
+	if days > 0 {
+		for !IsBusinessDay(current, cal) {
+			current = current.AddDate(0, 0, -1)
+		}
+	}
+
+	return current
+}
+
+// ShiftBusinessDays moves date forward (positive days) or backward (negative days) by business days
+func ShiftBusinessDays(date time.Time, days int, cal *model.Calendar) time.Time {
+	if days < 0 {
+		return SubtractBusinessDays(date, -days, cal)
+	}
+	return AddBusinessDays(date, days, cal)
+}
+
+// BusinessDaysBetween counts business days after from up to and including to
+// (the inverse of AddBusinessDays); the result is negative when to is before from
+func BusinessDaysBetween(from, to time.Time, cal *model.Calendar) int {
+	if cal == nil {
+		cal = DefaultCalendar()
+	}
+
+	if to.Before(from) {
+		return -BusinessDaysBetween(to, from, cal)
+	}
+
+	count := 0
+	for current := from.AddDate(0, 0, 1); !current.After(to); current = current.AddDate(0, 0, 1) {
+		if IsBusinessDay(current, cal) {
+			count++
+		}
+	}
+
+	return count
+}
+
 // IsBusinessDay checks if a date is a business day
 func IsBusinessDay(date time.Time, cal *model.Calendar) bool {
 	if cal == nil {
@@ -50,3 +50,116 @@ func TestAddBusinessDays(t *testing.T) {
 		})
 	}
 }
+
+func TestBusinessDaysBetween(t *testing.T) {
+	cal := &model.Calendar{
+		Weekends: []time.Weekday{time.Saturday, time.Sunday},
+		Holidays: []time.Time{
+			time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), // Monday holiday
+		},
+	}
+
+	tests := []struct {
+		name string
+		from time.Time
+		to   time.Time
+		want int
+	}{
+		{
+			name: "same day",
+			from: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+			to:   time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+			want: 0,
+		},
+		{
+			name: "across weekend and holiday",
+			from: time.Date(2023, 12, 29, 0, 0, 0, 0, time.UTC), // Friday
+			to:   time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),   // Tuesday
+			want: 1,
+		},
+		{
+			name: "backwards",
+			from: time.Date(2024, 1, 9, 0, 0, 0, 0, time.UTC),
+			to:   time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+			want: -5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := BusinessDaysBetween(tt.from, tt.to, cal)
+			if got != tt.want {
+				t.Errorf("BusinessDaysBetween() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSubtractBusinessDays(t *testing.T) {
+	cal := &model.Calendar{
+		Weekends: []time.Weekday{time.Saturday, time.Sunday},
+		Holidays: []time.Time{
+			time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), // Monday holiday
+		},
+	}
+
+	tests := []struct {
+		name string
+		end  time.Time
+		days int
+		want time.Time
+	}{
+		{
+			name: "subtract 1 day on Monday",
+			end:  time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC), // Monday
+			days: 1,
+			want: time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), // Friday (skip weekend)
+		},
+		{
+			name: "subtract across holiday",
+			end:  time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), // Tuesday after holiday
+			days: 1,
+			want: time.Date(2023, 12, 29, 0, 0, 0, 0, time.UTC), // Friday (skip holiday and weekend)
+		},
+		{
+			name: "subtract 5 days",
+			end:  time.Date(2024, 1, 9, 0, 0, 0, 0, time.UTC),
+			days: 5,
+			want: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "subtract 0 days",
+			end:  time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC), // Saturday
+			days: 0,
+			want: time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SubtractBusinessDays(tt.end, tt.days, cal)
+			if !got.Equal(tt.want) {
+				t.Errorf("SubtractBusinessDays() = %v, want %v", got, tt.want)
+			}
+
+			// Adding the days back must land on the original end date
+			if back := AddBusinessDays(got, tt.days, cal); !back.Equal(tt.end) {
+				t.Errorf("AddBusinessDays(SubtractBusinessDays()) = %v, want %v", back, tt.end)
+			}
+		})
+	}
+}
+
+func TestShiftBusinessDays(t *testing.T) {
+	friday := time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)
+
+	if got, want := ShiftBusinessDays(friday, 1, nil), time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("ShiftBusinessDays(+1) = %v, want %v", got, want)
+	}
+	if got, want := ShiftBusinessDays(friday, -5, nil), time.Date(2023, 12, 29, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("ShiftBusinessDays(-5) = %v, want %v", got, want)
+	}
+	if got := ShiftBusinessDays(friday, 0, nil); !got.Equal(friday) {
+		t.Errorf("ShiftBusinessDays(0) = %v, want %v", got, friday)
+	}
+}
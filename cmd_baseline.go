@@ -0,0 +1,70 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gantt-gen/baseline"
+	"gantt-gen/model"
+	"gantt-gen/resolver"
+)
+
+// runBaseline implements "gantt-gen baseline", saving the resolved schedule
+// to a sidecar JSON file for later variance reports
+func runBaseline(args []string) {
+	fs := flag.NewFlagSet("baseline", flag.ExitOnError)
+	levelResources := fs.Bool("level-resources", false, "Delay tasks so no assignee is booked on overlapping tasks")
+	fs.Parse(args)
+
+	if fs.NArg() < 1 || (fs.Arg(0) == "-" && fs.NArg() < 2) {
+		fmt.Fprintf(os.Stderr, "Usage: %s baseline [--level-resources] <input.md|-> [baseline.json]\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "The baseline defaults to <input>.baseline.json next to the input file\n")
+		os.Exit(1)
+	}
+
+	inputPath := fs.Arg(0)
+	outputPath := baselinePathFor(inputPath)
+	if fs.NArg() >= 2 {
+		outputPath = fs.Arg(1)
+	}
+
+	project, err := loadProject(inputPath, resolver.Options{LevelResources: *levelResources})
+	if err != nil {
+		reportError(err)
+		os.Exit(1)
+	}
+
+	snap := baseline.New(project)
+	if err := baseline.Save(outputPath, snap); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing baseline: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Fprintf(os.Stderr, "✓ Saved baseline of %d tasks: %s\n", len(snap.Tasks), outputPath)
+}
+
+// baselinePathFor returns the sidecar path for a plan, e.g. plan.md -> plan.baseline.json
+func baselinePathFor(inputPath string) string {
+	return strings.TrimSuffix(inputPath, filepath.Ext(inputPath)) + ".baseline.json"
+}
+
+// printVariance reports tasks whose dates have moved since the baseline
+func printVariance(project *model.Project, snap *baseline.Snapshot) {
+	var moved []baseline.Variance
+	for _, v := range snap.Compare(project) {
+		if v.Start != 0 || v.Finish != 0 {
+			moved = append(moved, v)
+		}
+	}
+	if len(moved) == 0 {
+		fmt.Fprintf(os.Stderr, "No variance from baseline\n")
+		return
+	}
+
+	fmt.Fprintf(os.Stderr, "Variance from baseline (business days):\n")
+	for _, v := range moved {
+		fmt.Fprintf(os.Stderr, "  %s: start %+d, finish %+d\n", v.Task, v.Start, v.Finish)
+	}
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"gantt-gen/baseline"
+	"gantt-gen/diff"
+	"gantt-gen/renderer"
+	"gantt-gen/resolver"
+)
+
+// runDiff implements "gantt-gen diff", comparing two versions of a plan
+func runDiff(args []string) {
+	fs := flag.NewFlagSet("diff", flag.ExitOnError)
+	format := fs.String("format", "text", "Report format: text, json, or html")
+	output := fs.String("o", "-", "Output file ('-' for stdout)")
+	levelResources := fs.Bool("level-resources", false, "Delay tasks so no assignee is booked on overlapping tasks")
+	fs.Parse(args)
+
+	if fs.NArg() != 2 {
+		fmt.Fprintf(os.Stderr, "Usage: %s diff [--format=text|json|html] [-o output] [--level-resources] <old.md> <new.md>\n", os.Args[0])
+		os.Exit(1)
+	}
+
+	reportFormat := strings.ToLower(*format)
+	if reportFormat != "text" && reportFormat != "json" && reportFormat != "html" {
+		fmt.Fprintf(os.Stderr, "Error: Invalid format '%s'. Use 'text', 'json', or 'html'\n", *format)
+		os.Exit(1)
+	}
+
+	resolveOpts := resolver.Options{LevelResources: *levelResources}
+	old, err := loadProject(fs.Arg(0), resolveOpts)
+	if err != nil {
+		reportLoadError(fs.Arg(0), err)
+		os.Exit(1)
+	}
+	current, err := loadProject(fs.Arg(1), resolveOpts)
+	if err != nil {
+		reportLoadError(fs.Arg(1), err)
+		os.Exit(1)
+	}
+
+	var buf bytes.Buffer
+	switch reportFormat {
+	case "json":
+		data, err := json.MarshalIndent(diff.Compare(old, current), "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding JSON: %v\n", err)
+			os.Exit(1)
+		}
+		buf.Write(data)
+		buf.WriteByte('\n')
+	case "html":
+		// The old plan's dates are drawn as ghost bars under the new schedule
+		html, err := renderer.RenderHTMLWithOptions(current, renderer.Options{Baseline: baseline.New(old)})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error rendering HTML: %v\n", err)
+			os.Exit(1)
+		}
+		buf.WriteString(html)
+	default: // text
+		diff.Compare(old, current).WriteText(&buf)
+	}
+
+	if err := writeOutput(*output, buf.Bytes()); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
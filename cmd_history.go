@@ -0,0 +1,60 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"gantt-gen/history"
+	"gantt-gen/renderer"
+	"gantt-gen/resolver"
+)
+
+// runHistory implements "gantt-gen history", resolving every git revision of
+// a plan to show how its dates moved
+func runHistory(args []string) {
+	fs := flag.NewFlagSet("history", flag.ExitOnError)
+	format := fs.String("format", "text", "Report format: text or html")
+	output := fs.String("o", "-", "Output file ('-' for stdout)")
+	levelResources := fs.Bool("level-resources", false, "Delay tasks so no assignee is booked on overlapping tasks")
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		fmt.Fprintf(os.Stderr, "Usage: %s history [--format=text|html] [-o output] [--level-resources] <plan.md>\n", os.Args[0])
+		os.Exit(1)
+	}
+
+	reportFormat := strings.ToLower(*format)
+	if reportFormat != "text" && reportFormat != "html" {
+		fmt.Fprintf(os.Stderr, "Error: Invalid format '%s'. Use 'text' or 'html'\n", *format)
+		os.Exit(1)
+	}
+
+	path := fs.Arg(0)
+	revs, load, err := history.Git(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading history: %v\n", err)
+		os.Exit(1)
+	}
+
+	report := history.Build(path, revs, load, resolver.Options{LevelResources: *levelResources})
+
+	var buf bytes.Buffer
+	if reportFormat == "html" {
+		html, err := renderer.RenderHistoryHTML(report)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error rendering history: %v\n", err)
+			os.Exit(1)
+		}
+		buf.WriteString(html)
+	} else {
+		report.WriteText(&buf)
+	}
+
+	if err := writeOutput(*output, buf.Bytes()); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"gantt-gen/lint"
+	"gantt-gen/resolver"
+)
+
+// runLint implements "gantt-gen lint", checking a plan against the
+// plan-quality rules in package lint. It exits with status 1 when anything is
+// reported.
+func runLint(args []string) {
+	fs := flag.NewFlagSet("lint", flag.ExitOnError)
+	configPath := fs.String("config", "", "JSON file enabling or disabling rules")
+	diagnostics := fs.String("diagnostics", "text", "Diagnostics format on stderr: text or json")
+	levelResources := fs.Bool("level-resources", false, "Delay tasks so no assignee is booked on overlapping tasks")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s lint [--config lint.json] [--diagnostics=text|json] [--level-resources] <plan.md>\n\nFlags:\n", os.Args[0])
+		fs.PrintDefaults()
+		fmt.Fprintf(os.Stderr, "\nRules:\n")
+		for _, rule := range lint.Rules {
+			fmt.Fprintf(os.Stderr, "  %-25s %s\n", rule.Name, rule.Description)
+		}
+	}
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		fs.Usage()
+		os.Exit(1)
+	}
+
+	switch strings.ToLower(*diagnostics) {
+	case "text":
+	case "json":
+		jsonDiagnostics = true
+	default:
+		fmt.Fprintf(os.Stderr, "Error: Invalid diagnostics format '%s'. Use 'text' or 'json'\n", *diagnostics)
+		os.Exit(1)
+	}
+
+	var cfg lint.Config
+	if *configPath != "" {
+		var err error
+		if cfg, err = lint.LoadConfig(*configPath); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	path := fs.Arg(0)
+	project, err := loadProject(path, resolver.Options{LevelResources: *levelResources})
+	if err != nil {
+		reportError(err)
+		flushDiagnostics()
+		os.Exit(1)
+	}
+
+	printProblems(path, project)
+	findings := lint.Run(project, cfg)
+	for _, diag := range findings {
+		// Name the rule so it can be found in the config
+		if !jsonDiagnostics {
+			diag.Message += " [" + diag.Code + "]"
+		}
+		emit(path, diag, string(diag.Severity))
+	}
+	flushDiagnostics()
+
+	if len(findings)+len(project.Warnings)+len(project.Conflicts) > 0 {
+		os.Exit(1)
+	}
+}
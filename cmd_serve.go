@@ -0,0 +1,198 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"html"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	"gantt-gen/renderer"
+	"gantt-gen/resolver"
+)
+
+// reloadScript reconnects to the server's event stream and reloads the page
+// whenever the plan is rebuilt
+const reloadScript = `<script>
+new EventSource("/events").onmessage = function () { location.reload(); };
+</script>`
+
+// errorOverlay is laid over the last good chart (or an empty page) while the plan has errors
+const errorOverlay = `<div id="gantt-gen-error" style="position: fixed; inset: 0; z-index: 1000; background: rgba(255, 255, 255, 0.92); font-family: Arial, sans-serif; padding: 40px;">
+    <div style="max-width: 900px; margin: 0 auto; border-left: 4px solid #c0392b; background: #fdf2f1; padding: 20px;">
+        <h2 style="margin-top: 0; color: #c0392b;">%s</h2>
+        <pre style="white-space: pre-wrap; font-size: 14px; color: #333;">%s</pre>
+    </div>
+</div>`
+
+// runServe implements "gantt-gen serve", a local preview of the HTML chart
+// that reloads in the browser whenever the plan changes
+func runServe(args []string) {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	addr := fs.String("addr", "localhost:8080", "Address to listen on")
+	levelResources := fs.Bool("level-resources", false, "Delay tasks so no assignee is booked on overlapping tasks")
+	groupBy := fs.String("group-by", "", "Group rows into swimlanes: assignee")
+	statusDateFlag := fs.String("status-date", "", "Status date for the progress line and overdue tasks (default today)")
+	fs.Parse(args)
+
+	if fs.NArg() != 1 || fs.Arg(0) == "-" {
+		fmt.Fprintf(os.Stderr, "Usage: %s serve [--addr=localhost:8080] [--level-resources] [--group-by=assignee] [--status-date=YYYY-MM-DD] <plan.md>\n", os.Args[0])
+		os.Exit(1)
+	}
+
+	statusDate, err := parseStatusDate(*statusDateFlag)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	opts := renderer.Options{StatusDate: statusDate, GroupBy: strings.ToLower(*groupBy)}
+	if opts.GroupBy != renderer.GroupByNone && opts.GroupBy != renderer.GroupByAssignee {
+		fmt.Fprintf(os.Stderr, "Error: Invalid group-by '%s'. Use 'assignee'\n", *groupBy)
+		os.Exit(1)
+	}
+
+	server := newPreviewServer(fs.Arg(0), renderConfig{
+		opts:        opts,
+		resolveOpts: resolver.Options{LevelResources: *levelResources},
+	})
+	go watchFiles([]string{server.path}, watchInterval, nil, server.rebuild)
+
+	fmt.Fprintf(os.Stderr, "Serving %s at http://%s (Ctrl+C to stop)\n", server.path, *addr)
+	if err := http.ListenAndServe(*addr, server); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// previewServer serves the latest rendering of a plan and tells connected
+// browsers to reload when it changes
+type previewServer struct {
+	path   string
+	cfg    renderConfig
+	format renderer.Format // The HTML format, for rendering and its Content-Type
+	mux    *http.ServeMux
+
+	mu       sync.Mutex
+	lastGood string // Last successful rendering, shown under the error overlay
+	page     string
+	clients  map[chan struct{}]bool
+}
+
+func newPreviewServer(path string, cfg renderConfig) *previewServer {
+	format, _ := renderer.Lookup("html")
+	s := &previewServer{
+		path:    path,
+		cfg:     cfg,
+		format:  format,
+		mux:     http.NewServeMux(),
+		clients: make(map[chan struct{}]bool),
+	}
+	s.mux.HandleFunc("/", s.handlePage)
+	s.mux.HandleFunc("/events", s.handleEvents)
+	return s
+}
+
+func (s *previewServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// rebuild re-renders the plan and notifies connected browsers
+func (s *previewServer) rebuild() {
+	page, err := s.render()
+
+	s.mu.Lock()
+	if err != nil {
+		reportError(err)
+		s.page = withErrorOverlay(s.lastGood, err)
+	} else {
+		fmt.Fprintf(os.Stderr, "✓ Rebuilt %s\n", s.path)
+		s.lastGood = page
+		s.page = page
+	}
+	for client := range s.clients {
+		select {
+		case client <- struct{}{}:
+		default: // A reload is already pending for this client
+		}
+	}
+	s.mu.Unlock()
+}
+
+func (s *previewServer) render() (string, error) {
+	project, err := loadProject(s.path, s.cfg.resolveOpts)
+	if err != nil {
+		return "", err
+	}
+	printProblems(s.path, project)
+	return s.format.Renderer.Render(project, s.cfg.opts)
+}
+
+// withErrorOverlay lays an error message over page, or over an empty page if
+// there has been no successful rendering yet
+func withErrorOverlay(page string, err error) string {
+	if page == "" {
+		page = "<!DOCTYPE html>\n<html>\n<head><meta charset=\"UTF-8\"><title>gantt-gen</title></head>\n<body>\n</body>\n</html>\n"
+	}
+	overlay := fmt.Sprintf(errorOverlay, "Plan has errors", html.EscapeString(err.Error()))
+	return insertBeforeBodyEnd(page, overlay)
+}
+
+// insertBeforeBodyEnd adds markup at the end of the page body
+func insertBeforeBodyEnd(page, markup string) string {
+	if i := strings.LastIndex(page, "</body>"); i >= 0 {
+		return page[:i] + markup + "\n" + page[i:]
+	}
+	return page + markup
+}
+
+func (s *previewServer) handlePage(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+
+	s.mu.Lock()
+	page := s.page
+	s.mu.Unlock()
+
+	w.Header().Set("Content-Type", s.format.MIMEType+"; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	fmt.Fprint(w, insertBeforeBodyEnd(page, reloadScript))
+}
+
+// handleEvents streams a server-sent event each time the plan is rebuilt
+func (s *previewServer) handleEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	client := make(chan struct{}, 1)
+	s.mu.Lock()
+	s.clients[client] = true
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.clients, client)
+		s.mu.Unlock()
+	}()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-client:
+			fmt.Fprint(w, "data: reload\n\n")
+			flusher.Flush()
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const servePlan = `# Preview
+
+## Design
+
+| Property | Value |
+|----------|-------|
+| Start | 2024-01-01 |
+| Duration | 5d |
+`
+
+func getPage(t *testing.T, url string) string {
+	t.Helper()
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatalf("GET %s: %v", url, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(body)
+}
+
+func TestPreviewServer(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "plan.md")
+	if err := os.WriteFile(path, []byte(servePlan), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	server := newPreviewServer(path, renderConfig{})
+	server.rebuild()
+
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	page := getPage(t, ts.URL)
+	if !strings.Contains(page, "Design") || !strings.Contains(page, `new EventSource("/events")`) {
+		t.Error("page should contain the chart and the reload script")
+	}
+	if strings.Contains(page, "gantt-gen-error") {
+		t.Error("valid plan should not show the error overlay")
+	}
+
+	// Subscribe to reloads, then break the plan
+	resp, err := http.Get(ts.URL + "/events")
+	if err != nil {
+		t.Fatalf("GET /events: %v", err)
+	}
+	defer resp.Body.Close()
+	events := bufio.NewReader(resp.Body)
+
+	if err := os.WriteFile(path, []byte(servePlan+"\n## Orphan\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	server.rebuild()
+
+	line := make(chan string, 1)
+	go func() {
+		s, _ := events.ReadString('\n')
+		line <- s
+	}()
+	select {
+	case got := <-line:
+		if got != "data: reload\n" {
+			t.Errorf("event = %q, want reload", got)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for reload event")
+	}
+
+	page = getPage(t, ts.URL)
+	if !strings.Contains(page, "gantt-gen-error") || !strings.Contains(page, "Orphan") {
+		t.Error("broken plan should show the error overlay")
+	}
+	if !strings.Contains(page, "Design") {
+		t.Error("overlay should sit on top of the last good chart")
+	}
+}
+
+func TestWithErrorOverlay_NoPreviousPage(t *testing.T) {
+	page := withErrorOverlay("", errors.New("task <X> is broken"))
+	if !strings.Contains(page, "task &lt;X&gt; is broken") {
+		t.Error("error message should be escaped")
+	}
+	if !strings.Contains(page, "</body>") {
+		t.Error("overlay should produce a complete page")
+	}
+}
+
+func TestParseStatusDate(t *testing.T) {
+	got, err := parseStatusDate("2024-01-10")
+	if err != nil || !got.Equal(time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("parseStatusDate(2024-01-10) = %v, %v", got, err)
+	}
+
+	// Serve and the main command both default to today
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if got, err := parseStatusDate(""); err != nil || !got.Equal(today) {
+		t.Errorf("parseStatusDate(\"\") = %v, %v, want %v", got, err, today)
+	}
+
+	if _, err := parseStatusDate("not a date"); err == nil {
+		t.Error("parseStatusDate() expected error for an invalid date")
+	}
+}
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"gantt-gen/model"
+)
+
+var (
+	// maxErrors caps how many plan errors reportError prints; 0 prints all.
+	// Set by --max-errors.
+	maxErrors int
+
+	// jsonDiagnostics collects problems for flushDiagnostics instead of
+	// printing them as text. Set by --diagnostics=json.
+	jsonDiagnostics bool
+	pending         []jsonDiagnostic
+)
+
+// jsonDiagnostic is one problem in --diagnostics=json output
+type jsonDiagnostic struct {
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+	Column   int    `json:"column"`
+	Severity string `json:"severity"`
+	Code     string `json:"code"`
+	Message  string `json:"message"`
+	Task     string `json:"task"`
+}
+
+// planError holds every problem that stopped a plan from loading, along with
+// any warnings found before it. It prints one plan.md:42:3: error: ... line per
+// problem so editors and CI can jump to them.
+type planError struct {
+	path  string
+	diags model.Diagnostics
+}
+
+func (e *planError) Error() string {
+	lines := make([]string, len(e.diags))
+	for i, diag := range e.diags {
+		lines[i] = formatDiagnostic(e.path, diag.Pos, string(diag.Severity), diag.Message)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (e *planError) Unwrap() error {
+	return e.diags
+}
+
+// sourceName is how messages refer to an input path
+func sourceName(path string) string {
+	if path == "-" {
+		return "<stdin>"
+	}
+	return path
+}
+
+// inPlan turns the diagnostics inside err, if any, into a planError for path,
+// led by the project's warnings (project may be nil)
+func inPlan(path string, project *model.Project, err error) error {
+	var diags model.Diagnostics
+	var diag model.Diagnostic
+	switch {
+	case errors.As(err, &diags):
+	case errors.As(err, &diag):
+		diags = model.Diagnostics{diag}
+	default:
+		return err
+	}
+
+	var all model.Diagnostics
+	if project != nil {
+		for _, warning := range project.Warnings {
+			all = append(all, warning.Diagnostic())
+		}
+	}
+	return &planError{path: path, diags: append(all, diags...)}
+}
+
+// reportError prints err on stderr. Plan problems are listed one per line,
+// with at most maxErrors errors; others get an "Error:" prefix.
+func reportError(err error) {
+	var planErr *planError
+	if !errors.As(err, &planErr) {
+		if jsonDiagnostics {
+			emit("", model.Diagnostic{Severity: model.SeverityError, Message: err.Error()}, "error")
+			return
+		}
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return
+	}
+
+	shown, hidden := 0, 0
+	for _, diag := range planErr.diags {
+		if diag.Severity == model.SeverityError {
+			if maxErrors > 0 && shown == maxErrors {
+				hidden++
+				continue
+			}
+			shown++
+		}
+		emit(planErr.path, diag, string(diag.Severity))
+	}
+	if hidden > 0 && !jsonDiagnostics {
+		fmt.Fprintf(os.Stderr, "... and %d more error(s); use --max-errors=0 to show all\n", hidden)
+	}
+}
+
+// reportLoadError prints an error from loading path, naming the file when the
+// error is not already located in it
+func reportLoadError(path string, err error) {
+	var planErr *planError
+	if errors.As(err, &planErr) {
+		reportError(err)
+		return
+	}
+	fmt.Fprintf(os.Stderr, "Error in %s: %v\n", path, err)
+}
+
+// formatDiagnostic formats a problem as "plan.md:42:3: warning: ...", or
+// "Warning: ..." when it has no position
+func formatDiagnostic(path string, pos model.Position, kind, message string) string {
+	if pos.IsValid() {
+		return fmt.Sprintf("%s:%s: %s: %s", sourceName(path), pos, kind, message)
+	}
+	return fmt.Sprintf("%s: %s", strings.ToUpper(kind[:1])+kind[1:], message)
+}
+
+// emit prints a problem on stderr labelled kind, or queues it for
+// flushDiagnostics in JSON mode
+func emit(path string, diag model.Diagnostic, kind string) {
+	if !jsonDiagnostics {
+		fmt.Fprintln(os.Stderr, formatDiagnostic(path, diag.Pos, kind, diag.Message))
+		return
+	}
+
+	var file string
+	if path != "" {
+		file = sourceName(path)
+	}
+	pending = append(pending, jsonDiagnostic{
+		File:     file,
+		Line:     diag.Pos.Line,
+		Column:   diag.Pos.Column,
+		Severity: string(diag.Severity),
+		Code:     diag.Code,
+		Message:  diag.Message,
+		Task:     diag.Task,
+	})
+}
+
+// flushDiagnostics prints the problems queued in JSON mode as one JSON array
+// on stderr, and nothing in text mode
+func flushDiagnostics() {
+	if !jsonDiagnostics {
+		return
+	}
+
+	out := pending
+	if out == nil {
+		out = []jsonDiagnostic{}
+	}
+	pending = nil
+
+	data, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error encoding diagnostics: %v\n", err)
+		return
+	}
+	fmt.Fprintf(os.Stderr, "%s\n", data)
+}
+
+// printProblems prints a project's warnings and conflicts. In text mode missed
+// deadlines are left out of the warnings and summarized in their own block.
+func printProblems(path string, project *model.Project) {
+	var missed []model.Warning
+	for _, warning := range project.Warnings {
+		if warning.Code == model.CodeMissedDeadline && !jsonDiagnostics {
+			missed = append(missed, warning)
+			continue
+		}
+		emit(path, warning.Diagnostic(), "warning")
+	}
+	for _, conflict := range project.Conflicts {
+		emit(path, conflict.Diagnostic(), "conflict")
+	}
+
+	if len(missed) == 0 {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "Missed deadlines (%d):\n", len(missed))
+	for _, warning := range missed {
+		if warning.Pos.IsValid() {
+			fmt.Fprintf(os.Stderr, "  %s:%s: %s\n", sourceName(path), warning.Pos, warning.Message)
+		} else {
+			fmt.Fprintf(os.Stderr, "  %s\n", warning.Message)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gantt-gen/model"
+	"gantt-gen/resolver"
+)
+
+func TestReportError_JSON(t *testing.T) {
+	jsonDiagnostics = true
+	t.Cleanup(func() {
+		jsonDiagnostics = false
+		maxErrors = 0
+		pending = nil
+	})
+
+	path := filepath.Join(t.TempDir(), "plan.md")
+	input := `# Project
+
+## Task A
+
+| Property | Value |
+|----------|-------|
+| Duration | 5 days |
+
+## Task B
+
+## Task C
+`
+	if err := os.WriteFile(path, []byte(input), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := loadProject(path, resolver.Options{})
+	if err == nil {
+		t.Fatal("loadProject() expected errors")
+	}
+
+	maxErrors = 2
+	reportError(err)
+
+	want := []jsonDiagnostic{
+		{File: path, Line: 7, Column: 3, Severity: "warning", Code: model.CodeInvalidDuration,
+			Message: `Duration "5 days" is not a duration like 5d, 2w or 1m; ignored`, Task: "Task A"},
+		{File: path, Line: 3, Column: 4, Severity: "error", Code: model.CodeNoTiming,
+			Message: "task Task A has no start date, date range, or dependencies", Task: "Task A"},
+		{File: path, Line: 9, Column: 4, Severity: "error", Code: model.CodeNoTiming,
+			Message: "task Task B has no start date, date range, or dependencies", Task: "Task B"},
+	}
+	if len(pending) != len(want) {
+		t.Fatalf("pending = %+v, want %d diagnostics", pending, len(want))
+	}
+	for i := range want {
+		if pending[i] != want[i] {
+			t.Errorf("diagnostic[%d] = %+v, want %+v", i, pending[i], want[i])
+		}
+	}
+}
+
+func TestPrintProblems_MissedDeadlines(t *testing.T) {
+	project := &model.Project{
+		Warnings: []model.Warning{
+			{Task: "Late", Code: model.CodeMissedDeadline, Pos: model.Position{Line: 8, Column: 3},
+				Message: `task "Late" finishes 2024-01-09, after its deadline 2024-01-05 (2 business days late)`},
+			{Task: "Other", Code: model.CodeUnknownProperty, Pos: model.Position{Line: 12, Column: 3},
+				Message: `unknown property "Owner"; ignored`},
+		},
+	}
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stderr := os.Stderr
+	os.Stderr = w
+	printProblems("plan.md", project)
+	os.Stderr = stderr
+	w.Close()
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `plan.md:12:3: warning: unknown property "Owner"; ignored
+Missed deadlines (1):
+  plan.md:8:3: task "Late" finishes 2024-01-09, after its deadline 2024-01-05 (2 business days late)
+`
+	if string(out) != want {
+		t.Errorf("printProblems() wrote:\n%s\nwant:\n%s", out, want)
+	}
+}
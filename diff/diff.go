@@ -0,0 +1,191 @@
+package diff
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"gantt-gen/calendar"
+	"gantt-gen/model"
+)
+
+const dateLayout = "2006-01-02"
+
+// Report lists the differences between two resolved versions of a plan
+type Report struct {
+	Added   []string     `json:"added"`
+	Removed []string     `json:"removed"`
+	Changed []TaskChange `json:"changed"`
+}
+
+// TaskChange describes how a task present in both versions changed; nil and
+// empty fields did not change
+type TaskChange struct {
+	Task                string      `json:"task"`
+	Duration            *IntChange  `json:"duration,omitempty"`
+	DependenciesAdded   []string    `json:"dependenciesAdded,omitempty"`
+	DependenciesRemoved []string    `json:"dependenciesRemoved,omitempty"`
+	Start               *DateChange `json:"start,omitempty"`
+	End                 *DateChange `json:"end,omitempty"`
+}
+
+// IntChange is an old and new value
+type IntChange struct {
+	Old int `json:"old"`
+	New int `json:"new"`
+}
+
+// DateChange is a moved date and the move in business days on the task's calendar
+type DateChange struct {
+	Old  string `json:"old"`
+	New  string `json:"new"`
+	Days int    `json:"days"`
+}
+
+// Compare diffs two resolved projects, matching tasks by name
+func Compare(before, after *model.Project) *Report {
+	// Empty lists rather than nil so JSON output always has arrays
+	report := &Report{Added: []string{}, Removed: []string{}, Changed: []TaskChange{}}
+
+	oldTasks := make(map[string]*model.Task)
+	for i := range before.Tasks {
+		oldTasks[before.Tasks[i].Name] = &before.Tasks[i]
+	}
+	newTasks := make(map[string]bool)
+
+	for i := range after.Tasks {
+		task := &after.Tasks[i]
+		newTasks[task.Name] = true
+
+		old, ok := oldTasks[task.Name]
+		if !ok {
+			report.Added = append(report.Added, task.Name)
+			continue
+		}
+		if change, changed := compareTask(old, task, after.CalendarFor(task)); changed {
+			report.Changed = append(report.Changed, change)
+		}
+	}
+
+	for _, task := range before.Tasks {
+		if !newTasks[task.Name] {
+			report.Removed = append(report.Removed, task.Name)
+		}
+	}
+
+	return report
+}
+
+// Empty reports whether the two versions schedule the same tasks identically
+func (r *Report) Empty() bool {
+	return len(r.Added) == 0 && len(r.Removed) == 0 && len(r.Changed) == 0
+}
+
+func compareTask(old, task *model.Task, cal *model.Calendar) (TaskChange, bool) {
+	change := TaskChange{Task: task.Name}
+	changed := false
+
+	if old.Duration != task.Duration {
+		change.Duration = &IntChange{Old: old.Duration, New: task.Duration}
+		changed = true
+	}
+
+	oldDeps := dependencySet(old)
+	newDeps := dependencySet(task)
+	for _, dep := range task.Dependencies {
+		if key := describeDependency(dep); !oldDeps[key] {
+			change.DependenciesAdded = append(change.DependenciesAdded, key)
+			changed = true
+		}
+	}
+	for _, dep := range old.Dependencies {
+		if key := describeDependency(dep); !newDeps[key] {
+			change.DependenciesRemoved = append(change.DependenciesRemoved, key)
+			changed = true
+		}
+	}
+
+	if c := compareDates(old.CalculatedStart, task.CalculatedStart, cal); c != nil {
+		change.Start = c
+		changed = true
+	}
+	if c := compareDates(old.CalculatedEnd, task.CalculatedEnd, cal); c != nil {
+		change.End = c
+		changed = true
+	}
+
+	return change, changed
+}
+
+func dependencySet(task *model.Task) map[string]bool {
+	set := make(map[string]bool)
+	for _, dep := range task.Dependencies {
+		set[describeDependency(dep)] = true
+	}
+	return set
+}
+
+// describeDependency renders a dependency as e.g. "Design (finish-to-start, +2d)"
+func describeDependency(dep model.Dependency) string {
+	depType := dep.Type
+	if depType == "" {
+		depType = model.FinishToStart
+	}
+	if dep.Lag != 0 {
+		return fmt.Sprintf("%s (%s, %+dd)", dep.TaskName, depType, dep.Lag)
+	}
+	return fmt.Sprintf("%s (%s)", dep.TaskName, depType)
+}
+
+func compareDates(old, current *time.Time, cal *model.Calendar) *DateChange {
+	if old == nil || current == nil || old.Equal(*current) {
+		return nil
+	}
+	return &DateChange{
+		Old:  old.Format(dateLayout),
+		New:  current.Format(dateLayout),
+		Days: calendar.BusinessDaysBetween(*old, *current, cal),
+	}
+}
+
+// WriteText writes a human-readable report
+func (r *Report) WriteText(w io.Writer) error {
+	if r.Empty() {
+		_, err := fmt.Fprintln(w, "No schedule changes")
+		return err
+	}
+
+	var err error
+	printf := func(format string, args ...interface{}) {
+		if err == nil {
+			_, err = fmt.Fprintf(w, format, args...)
+		}
+	}
+
+	for _, name := range r.Added {
+		printf("+ %s\n", name)
+	}
+	for _, name := range r.Removed {
+		printf("- %s\n", name)
+	}
+	for _, c := range r.Changed {
+		printf("~ %s\n", c.Task)
+		if c.Duration != nil {
+			printf("    duration: %dd -> %dd\n", c.Duration.Old, c.Duration.New)
+		}
+		for _, dep := range c.DependenciesAdded {
+			printf("    + depends on %s\n", dep)
+		}
+		for _, dep := range c.DependenciesRemoved {
+			printf("    - depends on %s\n", dep)
+		}
+		if c.Start != nil {
+			printf("    start: %s -> %s (%+d business days)\n", c.Start.Old, c.Start.New, c.Start.Days)
+		}
+		if c.End != nil {
+			printf("    end: %s -> %s (%+d business days)\n", c.End.Old, c.End.New, c.End.Days)
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,101 @@
+package diff
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"gantt-gen/model"
+)
+
+func date(day int) *time.Time {
+	t := time.Date(2024, 1, day, 0, 0, 0, 0, time.UTC)
+	return &t
+}
+
+func TestCompare(t *testing.T) {
+	old := &model.Project{
+		Tasks: []model.Task{
+			{Name: "Design", Duration: 4, CalculatedStart: date(1), CalculatedEnd: date(5)},
+			{
+				Name:            "Build",
+				Duration:        3,
+				Dependencies:    []model.Dependency{{TaskName: "Design", Type: model.FinishToStart}},
+				CalculatedStart: date(5),
+				CalculatedEnd:   date(10),
+			},
+			{Name: "Docs", Duration: 2, CalculatedStart: date(1), CalculatedEnd: date(3)},
+		},
+	}
+	after := &model.Project{
+		Tasks: []model.Task{
+			{Name: "Design", Duration: 6, CalculatedStart: date(1), CalculatedEnd: date(9)},
+			{
+				Name:            "Build",
+				Duration:        3,
+				Dependencies:    []model.Dependency{{TaskName: "Design", Type: model.FinishToStart, Lag: 1}},
+				CalculatedStart: date(10),
+				CalculatedEnd:   date(15),
+			},
+			{Name: "Review", Duration: 1, CalculatedStart: date(15), CalculatedEnd: date(16)},
+		},
+	}
+
+	report := Compare(old, after)
+
+	if strings.Join(report.Added, ",") != "Review" {
+		t.Errorf("Added = %v, want [Review]", report.Added)
+	}
+	if strings.Join(report.Removed, ",") != "Docs" {
+		t.Errorf("Removed = %v, want [Docs]", report.Removed)
+	}
+	if len(report.Changed) != 2 {
+		t.Fatalf("Changed = %+v, want Design and Build", report.Changed)
+	}
+
+	design := report.Changed[0]
+	if design.Duration == nil || design.Duration.Old != 4 || design.Duration.New != 6 {
+		t.Errorf("Design duration change = %+v, want 4 -> 6", design.Duration)
+	}
+	if design.Start != nil {
+		t.Errorf("Design start change = %+v, want none", design.Start)
+	}
+	if design.End == nil || design.End.Days != 2 {
+		t.Errorf("Design end change = %+v, want +2 business days", design.End)
+	}
+
+	build := report.Changed[1]
+	if strings.Join(build.DependenciesAdded, ",") != "Design (finish-to-start, +1d)" ||
+		strings.Join(build.DependenciesRemoved, ",") != "Design (finish-to-start)" {
+		t.Errorf("Build dependency changes = %v / %v", build.DependenciesAdded, build.DependenciesRemoved)
+	}
+	if build.Start == nil || build.Start.Days != 3 {
+		t.Errorf("Build start change = %+v, want +3 business days", build.Start)
+	}
+}
+
+func TestReport_WriteText(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (&Report{}).WriteText(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "No schedule changes\n" {
+		t.Errorf("WriteText() = %q for empty report", buf.String())
+	}
+
+	buf.Reset()
+	report := &Report{
+		Added: []string{"Review"},
+		Changed: []TaskChange{
+			{Task: "Design", End: &DateChange{Old: "2024-01-05", New: "2024-01-09", Days: 2}},
+		},
+	}
+	if err := report.WriteText(&buf); err != nil {
+		t.Fatal(err)
+	}
+	want := "+ Review\n~ Design\n    end: 2024-01-05 -> 2024-01-09 (+2 business days)\n"
+	if buf.String() != want {
+		t.Errorf("WriteText() = %q, want %q", buf.String(), want)
+	}
+}
@@ -0,0 +1,244 @@
+package history
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gantt-gen/calendar"
+	"gantt-gen/model"
+	"gantt-gen/parser"
+	"gantt-gen/resolver"
+)
+
+const dateLayout = "2006-01-02"
+
+// Revision is a commit that touched the plan
+type Revision struct {
+	Commit  string
+	Date    time.Time
+	Subject string
+	Path    string // The plan's path at this commit, relative to the repository root
+}
+
+// Point is the resolved schedule of the plan at one revision
+type Point struct {
+	Revision
+	Finish time.Time            // Projected project finish
+	Ends   map[string]time.Time // End date of each top-level task
+	Err    error                // Set when the revision could not be parsed or resolved
+
+	cal *model.Calendar // Default calendar at this revision, for measuring slips
+}
+
+// Report is how the schedule moved across the plan's history, oldest revision first
+type Report struct {
+	File   string
+	Tasks  []string // Top-level tasks, in first-seen document order
+	Points []Point
+}
+
+// Loader returns the plan's contents at a revision
+type Loader func(rev Revision) ([]byte, error)
+
+// Git returns the revisions of path from the local git log, oldest first,
+// and a Loader reading the file at each of them. History is followed across
+// renames, so each revision is read from the path the plan had at the time.
+func Git(path string) ([]Revision, Loader, error) {
+	dir, base := filepath.Split(path)
+	if dir == "" {
+		dir = "."
+	}
+
+	prefix, err := git(dir, "rev-parse", "--show-prefix")
+	if err != nil {
+		return nil, nil, err
+	}
+	current := strings.TrimSpace(string(prefix)) + base
+
+	// Each entry is a \x1e-led header line followed by the file's path at that commit
+	out, err := git(dir, "log", "--follow", "--name-only", "--format=%x1e%H%x1f%cI%x1f%s", "--", base)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var revs []Revision
+	for _, entry := range strings.Split(string(out), "\x1e") {
+		lines := strings.Split(strings.TrimSpace(entry), "\n")
+		fields := strings.SplitN(lines[0], "\x1f", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		date, err := time.Parse(time.RFC3339, fields[1])
+		if err != nil {
+			return nil, nil, fmt.Errorf("unexpected git date %q: %w", fields[1], err)
+		}
+
+		// Commits listed without a file keep the path of the newer revision
+		if name := strings.TrimSpace(lines[len(lines)-1]); len(lines) > 1 && name != "" {
+			current = name
+		}
+		revs = append(revs, Revision{Commit: fields[0], Date: date, Subject: fields[2], Path: current})
+	}
+	if len(revs) == 0 {
+		return nil, nil, fmt.Errorf("%s has no git history", path)
+	}
+
+	// git log lists newest first
+	for i, j := 0, len(revs)-1; i < j; i, j = i+1, j-1 {
+		revs[i], revs[j] = revs[j], revs[i]
+	}
+
+	load := func(rev Revision) ([]byte, error) {
+		return git(dir, "show", rev.Commit+":"+rev.Path)
+	}
+	return revs, load, nil
+}
+
+func git(dir string, args ...string) ([]byte, error) {
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git %s: %v: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
+
+// Build parses and resolves the plan at every revision
+func Build(file string, revs []Revision, load Loader, opts resolver.Options) *Report {
+	report := &Report{File: file}
+	seen := make(map[string]bool)
+
+	for _, rev := range revs {
+		point := Point{Revision: rev}
+
+		project, err := resolveRevision(rev, load, opts)
+		if err != nil {
+			point.Err = err
+			report.Points = append(report.Points, point)
+			continue
+		}
+
+		point.cal = project.DefaultCalendar()
+		point.Ends = make(map[string]time.Time)
+		for i := range project.Tasks {
+			task := &project.Tasks[i]
+			if task.CalculatedEnd == nil {
+				continue
+			}
+			if task.CalculatedEnd.After(point.Finish) {
+				point.Finish = *task.CalculatedEnd
+			}
+			if project.ParentIndex(i) != -1 {
+				continue
+			}
+			point.Ends[task.Name] = *task.CalculatedEnd
+			if !seen[task.Name] {
+				seen[task.Name] = true
+				report.Tasks = append(report.Tasks, task.Name)
+			}
+		}
+
+		report.Points = append(report.Points, point)
+	}
+
+	return report
+}
+
+func resolveRevision(rev Revision, load Loader, opts resolver.Options) (*model.Project, error) {
+	input, err := load(rev)
+	if err != nil {
+		return nil, err
+	}
+	project, err := parser.Parse(input)
+	if err != nil {
+		return nil, err
+	}
+	if err := project.Validate(); err != nil {
+		return nil, err
+	}
+	if err := resolver.ResolveWithOptions(project, opts); err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+// ShortCommit abbreviates a commit hash for display
+func (r Revision) ShortCommit() string {
+	if len(r.Commit) > 7 {
+		return r.Commit[:7]
+	}
+	return r.Commit
+}
+
+// Slip returns how far the projected finish moved since the previous
+// successfully resolved revision, in business days
+func (r *Report) Slip(i int) (int, bool) {
+	for prev := i - 1; prev >= 0; prev-- {
+		if r.Points[prev].Err == nil {
+			return calendar.BusinessDaysBetween(r.Points[prev].Finish, r.Points[i].Finish, r.Points[i].cal), true
+		}
+	}
+	return 0, false
+}
+
+// WriteText writes the finish date at each revision, then how each top-level
+// task's end moved between its first and latest appearance
+func (r *Report) WriteText(w io.Writer) error {
+	var err error
+	printf := func(format string, args ...interface{}) {
+		if err == nil {
+			_, err = fmt.Fprintf(w, format, args...)
+		}
+	}
+
+	printf("Schedule history of %s (%d revisions)\n\n", r.File, len(r.Points))
+
+	for i, p := range r.Points {
+		if p.Err != nil {
+			printf("%s  %s  error: %v  %s\n", p.Date.Format(dateLayout), p.ShortCommit(), p.Err, p.Subject)
+			continue
+		}
+		slip := ""
+		if days, ok := r.Slip(i); ok && days != 0 {
+			slip = fmt.Sprintf("%+dd", days)
+		}
+		printf("%s  %s  finish %s  %-5s  %s\n", p.Date.Format(dateLayout), p.ShortCommit(), p.Finish.Format(dateLayout), slip, p.Subject)
+	}
+
+	if len(r.Tasks) > 0 {
+		printf("\nTop-level task ends (first -> latest):\n")
+	}
+	for _, name := range r.Tasks {
+		first, last, cal, ok := r.taskRange(name)
+		if !ok {
+			continue
+		}
+		printf("  %s: %s -> %s (%+d business days)\n", name, first.Format(dateLayout), last.Format(dateLayout),
+			calendar.BusinessDaysBetween(first, last, cal))
+	}
+
+	return err
+}
+
+// taskRange returns a task's end at its first and latest resolved revisions
+func (r *Report) taskRange(name string) (first, last time.Time, cal *model.Calendar, ok bool) {
+	for _, p := range r.Points {
+		end, found := p.Ends[name]
+		if !found {
+			continue
+		}
+		if !ok {
+			first = end
+			ok = true
+		}
+		last, cal = end, p.cal
+	}
+	return first, last, cal, ok
+}
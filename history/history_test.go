@@ -0,0 +1,149 @@
+package history
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"gantt-gen/resolver"
+)
+
+func plan(designDays int) string {
+	return fmt.Sprintf(`# Project
+
+## Design
+
+| Property | Value |
+|----------|-------|
+| Start | 2024-01-01 |
+| Duration | %dd |
+
+### Mockups
+
+| Property | Value |
+|----------|-------|
+| Start | 2024-01-01 |
+| Duration | 2d |
+
+## Build
+
+| Property | Value |
+|----------|-------|
+| Duration | 5d |
+
+| Depends On | Type |
+|------------|------|
+| Design | finish-to-start |
+`, designDays)
+}
+
+func TestBuild(t *testing.T) {
+	revs := []Revision{
+		{Commit: "aaaaaaaaaa", Subject: "Initial plan"},
+		{Commit: "bbbbbbbbbb", Subject: "Broken"},
+		{Commit: "cccccccccc", Subject: "Longer design"},
+	}
+	contents := map[string]string{
+		"aaaaaaaaaa": plan(5),
+		"bbbbbbbbbb": plan(5) + "\n## Review\n",
+		"cccccccccc": plan(8),
+	}
+	load := func(rev Revision) ([]byte, error) {
+		return []byte(contents[rev.Commit]), nil
+	}
+
+	report := Build("plan.md", revs, load, resolver.Options{})
+
+	if strings.Join(report.Tasks, ",") != "Design,Build" {
+		t.Errorf("Tasks = %v, want top-level Design and Build", report.Tasks)
+	}
+	if len(report.Points) != 3 {
+		t.Fatalf("len(Points) = %d, want 3", len(report.Points))
+	}
+	if report.Points[1].Err == nil {
+		t.Error("Points[1].Err = nil, want the unresolvable revision's error")
+	}
+
+	wantFinish := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	if !report.Points[0].Finish.Equal(wantFinish) {
+		t.Errorf("Points[0].Finish = %v, want %v", report.Points[0].Finish, wantFinish)
+	}
+
+	// The failed revision is skipped when measuring the slip
+	if days, ok := report.Slip(2); !ok || days != 3 {
+		t.Errorf("Slip(2) = %d, %v, want 3, true", days, ok)
+	}
+	if _, ok := report.Slip(0); ok {
+		t.Error("Slip(0) should have no previous revision")
+	}
+
+	var buf bytes.Buffer
+	if err := report.WriteText(&buf); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"aaaaaaa", "+3d", "error:", "Build: 2024-01-15 -> 2024-01-18 (+3 business days)"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("WriteText() missing %q:\n%s", want, buf.String())
+		}
+	}
+}
+
+func TestGit(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	dir := t.TempDir()
+	run := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	if err := os.Mkdir(filepath.Join(dir, "plans"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	draft := filepath.Join(dir, "plans", "draft.md")
+	path := filepath.Join(dir, "plans", "plan.md")
+
+	run("init", "-q")
+	run("config", "user.email", "test@example.com")
+	run("config", "user.name", "Test")
+	if err := os.WriteFile(draft, []byte(plan(5)), 0644); err != nil {
+		t.Fatal(err)
+	}
+	run("add", "plans/draft.md")
+	run("commit", "-q", "-m", "Revision 1")
+
+	// Renaming the plan keeps its earlier history
+	run("mv", "plans/draft.md", "plans/plan.md")
+	if err := os.WriteFile(path, []byte(plan(8)), 0644); err != nil {
+		t.Fatal(err)
+	}
+	run("add", "plans/plan.md")
+	run("commit", "-q", "-m", "Revision 2")
+
+	revs, load, err := Git(path)
+	if err != nil {
+		t.Fatalf("Git() error = %v", err)
+	}
+	if len(revs) != 2 || revs[0].Subject != "Revision 1" || revs[1].Subject != "Revision 2" {
+		t.Fatalf("Git() revisions = %+v, want oldest first", revs)
+	}
+
+	content, err := load(revs[0])
+	if err != nil {
+		t.Fatalf("load() error = %v", err)
+	}
+	if string(content) != plan(5) {
+		t.Errorf("load(Revision 1) returned the wrong contents")
+	}
+	if revs[0].Path != "plans/draft.md" || revs[1].Path != "plans/plan.md" {
+		t.Errorf("Git() paths = %q, %q, want plans/draft.md, plans/plan.md", revs[0].Path, revs[1].Path)
+	}
+}
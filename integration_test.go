@@ -2,6 +2,7 @@ package main
 
 import (
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -97,6 +98,42 @@ func TestFullPipeline_ValidationFailure(t *testing.T) {
 	}
 }
 
+func TestLoadProject_Errors(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "plan.md")
+	input := `# Project
+
+## Task A
+
+| Property | Value |
+|----------|-------|
+| Start | 2024-01-01 |
+
+## Task B
+
+| Depends On | Type |
+|------------|------|
+| Task C | finish-to-start |
+
+## Task A
+`
+	if err := os.WriteFile(path, []byte(input), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := loadProject(path, resolver.Options{})
+	if err == nil {
+		t.Fatal("loadProject() expected errors")
+	}
+
+	want := []string{
+		path + `:15:4: error: duplicate task name: Task A`,
+		path + `:13:3: error: task "Task B" depends on non-existent task: Task C`,
+	}
+	if err.Error() != strings.Join(want, "\n") {
+		t.Errorf("loadProject() error =\n%s\nwant\n%s", err, strings.Join(want, "\n"))
+	}
+}
+
 func TestFullPipeline_RealFile(t *testing.T) {
 	// Test with actual example file
 	input, err := os.ReadFile("examples/sample-project.md")
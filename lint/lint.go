@@ -0,0 +1,334 @@
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"gantt-gen/model"
+)
+
+const dateLayout = "2006-01-02"
+
+// DefaultMaxDurationWeeks is the long-duration threshold when the config does not set one
+const DefaultMaxDurationWeeks = 8
+
+// Config selects the rules to run. Rules not listed in Rules are enabled.
+type Config struct {
+	Rules            map[string]bool `json:"rules"`
+	MaxDurationWeeks int             `json:"max_duration_weeks"` // Threshold for long-duration
+}
+
+// Rule is a plan-quality check
+type Rule struct {
+	Name        string
+	Description string
+	check       func(project *model.Project, cfg Config) []model.Diagnostic
+}
+
+// Rules lists every rule in the order they run
+var Rules = []Rule{
+	{"dangling-work", "Task that is not a milestone and that nothing depends on", checkDanglingWork},
+	{"no-predecessors", "Task with no dependencies and no explicit Start or Date", checkNoPredecessors},
+	{"long-duration", "Duration longer than max_duration_weeks; consider splitting the task", checkLongDuration},
+	{"dependency-into-sibling", "Dependency on a task nested inside a sibling heading instead of the sibling", checkDependencyIntoSibling},
+	{"redundant-dependency", "Finish-to-start dependency already implied by another dependency", checkRedundantDependency},
+	{"unused-calendar", "Calendar that is neither the default nor named by any task", checkUnusedCalendar},
+	{"holiday-out-of-range", "Holiday outside the scheduled range of the plan", checkHolidayOutOfRange},
+}
+
+// Enabled reports whether the config runs the named rule
+func (c Config) Enabled(name string) bool {
+	enabled, ok := c.Rules[name]
+	return !ok || enabled
+}
+
+// LoadConfig reads a JSON config such as
+//
+//	{"rules": {"redundant-dependency": false}, "max_duration_weeks": 6}
+func LoadConfig(path string) (Config, error) {
+	var cfg Config
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cfg, fmt.Errorf("reading lint config: %w", err)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&cfg); err != nil {
+		return cfg, fmt.Errorf("parsing lint config %s: %w", path, err)
+	}
+
+	for name := range cfg.Rules {
+		if !knownRule(name) {
+			return cfg, fmt.Errorf("lint config %s: unknown rule %q", path, name)
+		}
+	}
+	if cfg.MaxDurationWeeks < 0 {
+		return cfg, fmt.Errorf("lint config %s: max_duration_weeks must not be negative", path)
+	}
+	return cfg, nil
+}
+
+func knownRule(name string) bool {
+	for _, rule := range Rules {
+		if rule.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Run checks a resolved project with the enabled rules. Findings are warnings
+// whose Code is the rule name, ordered by position in the source.
+func Run(project *model.Project, cfg Config) model.Diagnostics {
+	var diags model.Diagnostics
+	for _, rule := range Rules {
+		if cfg.Enabled(rule.Name) {
+			diags = append(diags, rule.check(project, cfg)...)
+		}
+	}
+
+	sort.SliceStable(diags, func(i, j int) bool {
+		a, b := diags[i].Pos, diags[j].Pos
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	return diags
+}
+
+func finding(rule string, pos model.Position, task string, format string, args ...interface{}) model.Diagnostic {
+	return model.Diagnostic{
+		Severity: model.SeverityWarning,
+		Code:     rule,
+		Pos:      pos,
+		Task:     task,
+		Message:  fmt.Sprintf(format, args...),
+	}
+}
+
+// isLeaf returns true for work items: tasks that are not milestones and have
+// no nested headings
+func isLeaf(project *model.Project, index int) bool {
+	task := &project.Tasks[index]
+	return !task.IsMilestone && task.Date == nil && len(project.ChildIndices(index)) == 0
+}
+
+// ancestors returns the indices of the headings a task is nested under, innermost first
+func ancestors(project *model.Project, index int) []int {
+	var result []int
+	for parent := project.ParentIndex(index); parent >= 0; parent = project.ParentIndex(parent) {
+		result = append(result, parent)
+	}
+	return result
+}
+
+func checkDanglingWork(project *model.Project, cfg Config) []model.Diagnostic {
+	hasSuccessor := make(map[string]bool)
+	for _, task := range project.Tasks {
+		for _, dep := range task.Dependencies {
+			hasSuccessor[dep.TaskName] = true
+		}
+	}
+
+	var diags []model.Diagnostic
+	for i := range project.Tasks {
+		if !isLeaf(project, i) || hasSuccessor[project.Tasks[i].Name] {
+			continue
+		}
+
+		// Depending on a summary heading also depends on everything under it
+		covered := false
+		for _, parent := range ancestors(project, i) {
+			if hasSuccessor[project.Tasks[parent].Name] {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			task := &project.Tasks[i]
+			diags = append(diags, finding("dangling-work", task.Pos, task.Name,
+				"task %q is not a milestone and nothing depends on it", task.Name))
+		}
+	}
+	return diags
+}
+
+func checkNoPredecessors(project *model.Project, cfg Config) []model.Diagnostic {
+	var diags []model.Diagnostic
+	for i := range project.Tasks {
+		task := &project.Tasks[i]
+		if isLeaf(project, i) && task.Start == nil && len(task.Dependencies) == 0 {
+			diags = append(diags, finding("no-predecessors", task.Pos, task.Name,
+				"task %q has no dependencies and no explicit Start", task.Name))
+		}
+	}
+	return diags
+}
+
+func checkLongDuration(project *model.Project, cfg Config) []model.Diagnostic {
+	weeks := cfg.MaxDurationWeeks
+	if weeks == 0 {
+		weeks = DefaultMaxDurationWeeks
+	}
+
+	var diags []model.Diagnostic
+	for i := range project.Tasks {
+		task := &project.Tasks[i]
+		if task.Duration > weeks*5 {
+			diags = append(diags, finding("long-duration", task.PropertyPosition("Duration"), task.Name,
+				"task %q lasts %d business days, more than %d weeks", task.Name, task.Duration, weeks))
+		}
+	}
+	return diags
+}
+
+func checkDependencyIntoSibling(project *model.Project, cfg Config) []model.Diagnostic {
+	index := make(map[string]int)
+	for i := range project.Tasks {
+		index[project.Tasks[i].Name] = i
+	}
+
+	var diags []model.Diagnostic
+	for i := range project.Tasks {
+		task := &project.Tasks[i]
+		parent := project.ParentIndex(i)
+
+		for _, dep := range task.Dependencies {
+			target, ok := index[dep.TaskName]
+			if !ok {
+				continue
+			}
+
+			// Climb from the target to the heading that shares the task's parent
+			sibling := target
+			for {
+				up := project.ParentIndex(sibling)
+				if up == parent {
+					break
+				}
+				if up < 0 {
+					sibling = -1
+					break
+				}
+				sibling = up
+			}
+
+			if sibling >= 0 && sibling != target && sibling != i {
+				diags = append(diags, finding("dependency-into-sibling", dep.Pos, task.Name,
+					"task %q depends on %q inside sibling %q; depend on %q instead",
+					task.Name, dep.TaskName, project.Tasks[sibling].Name, project.Tasks[sibling].Name))
+			}
+		}
+	}
+	return diags
+}
+
+// impliesFinish reports whether a chain of finish-to-start dependencies
+// without lead time leads from task to target, so task cannot start before
+// target finishes
+func impliesFinish(tasks map[string]*model.Task, task, target string, seen map[string]bool) bool {
+	if task == target {
+		return true
+	}
+	if seen[task] {
+		return false
+	}
+	seen[task] = true
+
+	current, ok := tasks[task]
+	if !ok {
+		return false
+	}
+	for _, dep := range current.Dependencies {
+		if dep.Type == model.FinishToStart && dep.Lag >= 0 && impliesFinish(tasks, dep.TaskName, target, seen) {
+			return true
+		}
+	}
+	return false
+}
+
+func checkRedundantDependency(project *model.Project, cfg Config) []model.Diagnostic {
+	tasks := make(map[string]*model.Task)
+	for i := range project.Tasks {
+		tasks[project.Tasks[i].Name] = &project.Tasks[i]
+	}
+
+	var diags []model.Diagnostic
+	for i := range project.Tasks {
+		task := &project.Tasks[i]
+		for _, dep := range task.Dependencies {
+			if dep.Type != model.FinishToStart || dep.Lag != 0 {
+				continue
+			}
+
+			for _, via := range task.Dependencies {
+				if via.TaskName == dep.TaskName || via.Type != model.FinishToStart || via.Lag < 0 {
+					continue
+				}
+				if impliesFinish(tasks, via.TaskName, dep.TaskName, make(map[string]bool)) {
+					diags = append(diags, finding("redundant-dependency", dep.Pos, task.Name,
+						"task %q depends on %q, which is already implied through %q", task.Name, dep.TaskName, via.TaskName))
+					break
+				}
+			}
+		}
+	}
+	return diags
+}
+
+func checkUnusedCalendar(project *model.Project, cfg Config) []model.Diagnostic {
+	used := make(map[string]bool)
+	for _, task := range project.Tasks {
+		used[task.CalendarName] = true
+	}
+
+	var diags []model.Diagnostic
+	for _, cal := range project.Calendars {
+		if !cal.IsDefault && !used[cal.Name] {
+			diags = append(diags, finding("unused-calendar", cal.Pos, "",
+				"calendar %q is not the default and no task uses it", cal.Name))
+		}
+	}
+	return diags
+}
+
+func checkHolidayOutOfRange(project *model.Project, cfg Config) []model.Diagnostic {
+	var start, end time.Time
+	for _, task := range project.Tasks {
+		if task.CalculatedStart == nil || task.CalculatedEnd == nil {
+			continue
+		}
+		if start.IsZero() || task.CalculatedStart.Before(start) {
+			start = *task.CalculatedStart
+		}
+		if end.IsZero() || task.CalculatedEnd.After(end) {
+			end = *task.CalculatedEnd
+		}
+	}
+	if start.IsZero() {
+		return nil
+	}
+
+	var diags []model.Diagnostic
+	for _, cal := range project.Calendars {
+		var outside []string
+		for _, holiday := range cal.Holidays {
+			if holiday.Before(start) || holiday.After(end) {
+				outside = append(outside, holiday.Format(dateLayout))
+			}
+		}
+		if len(outside) > 0 {
+			diags = append(diags, finding("holiday-out-of-range", cal.Pos, "",
+				"calendar %q has holidays outside the plan (%s - %s): %s",
+				cal.Name, start.Format(dateLayout), end.Format(dateLayout), strings.Join(outside, ", ")))
+		}
+	}
+	return diags
+}
@@ -0,0 +1,278 @@
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gantt-gen/model"
+	"gantt-gen/parser"
+	"gantt-gen/resolver"
+)
+
+func load(t *testing.T, input string) *model.Project {
+	t.Helper()
+	project, err := parser.Parse([]byte(input))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if err := resolver.Resolve(project); err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	return project
+}
+
+// onlyRule returns a config running just the named rule
+func onlyRule(name string) Config {
+	cfg := Config{Rules: make(map[string]bool)}
+	for _, rule := range Rules {
+		cfg.Rules[rule.Name] = rule.Name == name
+	}
+	return cfg
+}
+
+func TestRules(t *testing.T) {
+	tests := []struct {
+		rule  string
+		input string
+		want  []string // Task, or calendar name, of each finding
+	}{
+		{
+			rule: "dangling-work",
+			input: `## Phase
+### Design
+
+| Property | Value |
+|---|---|
+| Start | 2024-01-01 |
+| Duration | 5d |
+
+### Spike
+
+| Property | Value |
+|---|---|
+| Start | 2024-01-01 |
+| Duration | 2d |
+
+## Build
+
+| Depends On | Type |
+|---|---|
+| Phase | finish-to-start |
+
+**Launch**
+
+| Depends On | Type |
+|---|---|
+| Build | finish-to-start |
+`,
+			want: nil, // Depending on Phase covers Design and Spike; Launch is a milestone
+		},
+		{
+			rule: "dangling-work",
+			input: `## Design
+| Property | Value |
+|---|---|
+| Start | 2024-01-01 |
+| Duration | 5d |
+
+## Docs
+
+| Depends On | Type |
+|---|---|
+| Design | finish-to-start |
+`,
+			want: []string{"Docs"},
+		},
+		{
+			rule: "no-predecessors",
+			input: `## Design
+| Property | Value |
+|---|---|
+| Start | 2024-01-01 |
+| Duration | 5d |
+
+## Docs
+
+| Property | Value |
+|---|---|
+| Not Before | 2024-01-03 |
+| Duration | 2d |
+`,
+			want: []string{"Docs"},
+		},
+		{
+			rule: "long-duration",
+			input: `## Build
+| Property | Value |
+|---|---|
+| Start | 2024-01-01 |
+| Duration | 9w |
+
+## Test
+
+| Property | Value |
+|---|---|
+| Start | 2024-01-01 |
+| Duration | 8w |
+`,
+			want: []string{"Build"},
+		},
+		{
+			rule: "dependency-into-sibling",
+			input: `## Build
+### Backend
+
+| Property | Value |
+|---|---|
+| Start | 2024-01-01 |
+| Duration | 5d |
+
+### Frontend
+
+| Depends On | Type |
+|---|---|
+| Backend | finish-to-start |
+
+## Test
+
+| Depends On | Type |
+|---|---|
+| Backend | finish-to-start |
+| Build | finish-to-start |
+`,
+			want: []string{"Test"}, // Frontend -> Backend stays inside Build
+		},
+		{
+			rule: "redundant-dependency",
+			input: `## Design
+| Property | Value |
+|---|---|
+| Start | 2024-01-01 |
+| Duration | 5d |
+
+## Build
+
+| Depends On | Type |
+|---|---|
+| Design | finish-to-start |
+
+## Test
+
+| Depends On | Type |
+|---|---|
+| Build | finish-to-start |
+| Design | finish-to-start |
+
+## Review
+
+| Depends On | Type |
+|---|---|
+| Build | start-to-start |
+| Design | finish-to-start |
+`,
+			want: []string{"Test"}, // Start-to-start does not imply Design finished
+		},
+		{
+			rule: "unused-calendar",
+			input: `## Calendar: Office
+| Type | Value |
+|---|---|
+| Default | true |
+
+## Calendar: Plant
+
+| Type | Value |
+|---|---|
+| Weekends | Sun |
+
+## Calendar: Spare
+
+| Type | Value |
+|---|---|
+| Weekends | Sat |
+
+## Build
+
+| Property | Value |
+|---|---|
+| Start | 2024-01-01 |
+| Calendar | Plant |
+`,
+			want: []string{"Spare"},
+		},
+		{
+			rule: "holiday-out-of-range",
+			input: `## Calendar: Office
+| Type | Value |
+|---|---|
+| Default | true |
+| Holiday | 2024-01-03 |
+| Holiday | 2024-06-01 |
+
+## Build
+
+| Property | Value |
+|---|---|
+| Start | 2024-01-01 |
+| Duration | 5d |
+`,
+			want: []string{"Office"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			project := load(t, tt.input)
+			diags := Run(project, onlyRule(tt.rule))
+
+			if len(diags) != len(tt.want) {
+				t.Fatalf("Run() = %v, want %d findings", diags, len(tt.want))
+			}
+			for i, want := range tt.want {
+				diag := diags[i]
+				subject := diag.Task
+				if subject == "" {
+					// Calendar findings name the calendar in the message
+					subject = want
+					if !strings.Contains(diag.Message, `"`+want+`"`) {
+						t.Errorf("finding[%d] = %q, want it about %q", i, diag.Message, want)
+					}
+				}
+				if subject != want || diag.Code != tt.rule || diag.Severity != model.SeverityWarning || !diag.Pos.IsValid() {
+					t.Errorf("finding[%d] = %+v, want %s warning about %q", i, diag, tt.rule, want)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	cfg, err := LoadConfig(write("lint.json", `{"rules": {"dangling-work": false}, "max_duration_weeks": 4}`))
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if cfg.Enabled("dangling-work") || !cfg.Enabled("long-duration") || cfg.MaxDurationWeeks != 4 {
+		t.Errorf("LoadConfig() = %+v", cfg)
+	}
+
+	for name, content := range map[string]string{
+		"unknown-rule.json":  `{"rules": {"no-such-rule": true}}`,
+		"unknown-field.json": `{"rule": {"dangling-work": false}}`,
+		"negative.json":      `{"max_duration_weeks": -1}`,
+	} {
+		if _, err := LoadConfig(write(name, content)); err == nil {
+			t.Errorf("LoadConfig(%s) expected error", name)
+		}
+	}
+}
@@ -6,106 +6,263 @@ import (
 	"io"
 	"os"
 	"strings"
+	"time"
 
+	"github.com/araddon/dateparse"
+
+	"gantt-gen/baseline"
+	"gantt-gen/model"
 	"gantt-gen/parser"
 	"gantt-gen/renderer"
 	"gantt-gen/resolver"
 )
 
 func main() {
+	// Subcommands
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "baseline":
+			runBaseline(os.Args[2:])
+			return
+		case "diff":
+			runDiff(os.Args[2:])
+			return
+		case "history":
+			runHistory(os.Args[2:])
+			return
+		case "lint":
+			runLint(os.Args[2:])
+			return
+		case "serve":
+			runServe(os.Args[2:])
+			return
+		}
+	}
+
 	// Define flags
-	format := flag.String("format", "svg", "Output format: svg, html, or confluence")
+	format := &formatFlag{value: "svg"}
+	flag.Var(format, "format", "Output format (applies to the outputs after it); see Formats below")
+	outputs := &outputsFlag{format: format}
+	flag.Var(outputs, "o", "Output file, repeatable; format from --format or the file extension")
+	statusDateFlag := flag.String("status-date", "", "Status date for the progress line and overdue tasks (default today)")
+	groupBy := flag.String("group-by", "", "Group rows into swimlanes: assignee")
+	levelResources := flag.Bool("level-resources", false, "Delay tasks so no assignee is booked on overlapping tasks")
+	strict := flag.Bool("strict", false, "Fail when the schedule has conflicting constraints")
+	werror := flag.Bool("Werror", false, "Fail when the plan has warnings, such as ignored or unparseable input")
+	watch := flag.Bool("watch", false, "Keep running and regenerate the output whenever the input file changes (only that file is watched)")
+	baselinePath := flag.String("baseline", "", "Baseline JSON from 'gantt-gen baseline' to draw ghost bars and variance against")
+	flag.IntVar(&maxErrors, "max-errors", 0, "Print at most this many plan errors (0 for all)")
+	diagnostics := flag.String("diagnostics", "text", "Diagnostics format on stderr: text or json")
+	flag.Usage = usage
 	flag.Parse()
 
 	// Check remaining arguments
 	args := flag.Args()
-	if len(args) < 2 {
-		fmt.Fprintf(os.Stderr, "Usage: %s [--format=svg|html|confluence] <input.md|-> <output-file|->\n", os.Args[0])
-		fmt.Fprintf(os.Stderr, "Use '-' for stdin (input) or stdout (output)\n")
+	if len(args) < 1 || (len(args) < 2 && len(outputs.specs) == 0) {
+		usage()
 		os.Exit(1)
 	}
 
 	inputPath := args[0]
-	outputPath := args[1]
+	specs, err := positionalOutputs(args, outputs)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n\n", err)
+		usage()
+		os.Exit(1)
+	}
+
+	statusDate, err := parseStatusDate(*statusDateFlag)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	opts := renderer.Options{
+		StatusDate: statusDate,
+		GroupBy:    strings.ToLower(*groupBy),
+	}
+	if opts.GroupBy != renderer.GroupByNone && opts.GroupBy != renderer.GroupByAssignee {
+		fmt.Fprintf(os.Stderr, "Error: Invalid group-by '%s'. Use 'assignee'\n", *groupBy)
+		os.Exit(1)
+	}
 
-	// Validate format
-	outputFormat := strings.ToLower(*format)
-	if outputFormat != "svg" && outputFormat != "html" && outputFormat != "confluence" {
-		fmt.Fprintf(os.Stderr, "Error: Invalid format '%s'. Use 'svg', 'html', or 'confluence'\n", outputFormat)
+	switch strings.ToLower(*diagnostics) {
+	case "text":
+	case "json":
+		jsonDiagnostics = true
+	default:
+		fmt.Fprintf(os.Stderr, "Error: Invalid diagnostics format '%s'. Use 'text' or 'json'\n", *diagnostics)
 		os.Exit(1)
 	}
 
-	// Read input file or stdin
-	var input []byte
-	if inputPath == "-" {
-		var err error
-		input, err = io.ReadAll(os.Stdin)
+	if *baselinePath != "" {
+		snap, err := baseline.Load(*baselinePath)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error reading from stdin: %v\n", err)
+			fmt.Fprintf(os.Stderr, "Error reading baseline: %v\n", err)
 			os.Exit(1)
 		}
-	} else {
-		var err error
-		input, err = os.ReadFile(inputPath)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error reading input file: %v\n", err)
+		opts.Baseline = snap
+	}
+
+	cfg := renderConfig{
+		opts:        opts,
+		resolveOpts: resolver.Options{LevelResources: *levelResources},
+		strict:      *strict,
+		werror:      *werror,
+	}
+
+	if *watch {
+		if inputPath == "-" {
+			fmt.Fprintf(os.Stderr, "Error: --watch needs an input file, not stdin\n")
 			os.Exit(1)
 		}
+		fmt.Fprintf(os.Stderr, "Watching %s for changes (Ctrl+C to stop)\n", inputPath)
+		watchFiles([]string{inputPath}, watchInterval, nil, func() {
+			if err := generate(inputPath, specs, cfg); err != nil {
+				reportError(err)
+			}
+			flushDiagnostics()
+		})
+		return
 	}
 
-	// Parse markdown
-	project, err := parser.Parse(input)
+	err = generate(inputPath, specs, cfg)
+	if err != nil {
+		reportError(err)
+	}
+	flushDiagnostics()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error parsing markdown: %v\n", err)
 		os.Exit(1)
 	}
+}
 
-	// Validate project structure
-	if err := project.Validate(); err != nil {
-		fmt.Fprintf(os.Stderr, "Validation error: %v\n", err)
-		os.Exit(1)
+// usage prints the command line synopsis, flags and registered formats
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage: %s [flags] <input.md|-> <output-file|->\n", os.Args[0])
+	fmt.Fprintf(os.Stderr, "       %s [flags] [--format=...] -o <output-file> [-o <output-file>...] <input.md|->\n", os.Args[0])
+	fmt.Fprintf(os.Stderr, "       %s baseline [--level-resources] <input.md> [baseline.json]\n", os.Args[0])
+	fmt.Fprintf(os.Stderr, "       %s diff [--format=text|json|html] [-o output] <old.md> <new.md>\n", os.Args[0])
+	fmt.Fprintf(os.Stderr, "       %s history [--format=text|html] [-o output] <plan.md>\n", os.Args[0])
+	fmt.Fprintf(os.Stderr, "       %s serve [--addr=localhost:8080] [--status-date=YYYY-MM-DD] <plan.md>\n", os.Args[0])
+	fmt.Fprintf(os.Stderr, "       %s lint [--config lint.json] <plan.md>\n", os.Args[0])
+	fmt.Fprintf(os.Stderr, "Use '-' for stdin (input) or stdout (output)\n\nFlags:\n")
+	flag.PrintDefaults()
+	printFormats()
+}
+
+// parseStatusDate parses a --status-date value, defaulting to today when it is empty
+func parseStatusDate(value string) (time.Time, error) {
+	if value == "" {
+		now := time.Now()
+		return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC), nil
 	}
 
-	// Resolve dependencies and calculate dates
-	if err := resolver.Resolve(project); err != nil {
-		fmt.Fprintf(os.Stderr, "Error resolving dependencies: %v\n", err)
-		os.Exit(1)
+	parsed, err := dateparse.ParseAny(value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid status date '%s': %v", value, err)
+	}
+	return parsed, nil
+}
+
+// renderConfig holds the settings for turning a plan into charts
+type renderConfig struct {
+	opts        renderer.Options
+	resolveOpts resolver.Options
+	strict      bool
+	werror      bool
+}
+
+// generate runs the whole pipeline once: load and resolve the plan, report
+// problems, then render and write every output
+func generate(inputPath string, outputs []outputSpec, cfg renderConfig) error {
+	project, err := loadProject(inputPath, cfg.resolveOpts)
+	if err != nil {
+		return err
+	}
+
+	printProblems(inputPath, project)
+	if cfg.opts.Baseline != nil && !jsonDiagnostics {
+		printVariance(project, cfg.opts.Baseline)
 	}
 
-	// Generate output based on format
-	var output string
-	switch outputFormat {
-	case "html":
-		output, err = renderer.RenderHTML(project)
+	if cfg.werror && len(project.Warnings) > 0 {
+		return fmt.Errorf("%d warning(s) with --Werror", len(project.Warnings))
+	}
+	if cfg.strict && len(project.Conflicts) > 0 {
+		return fmt.Errorf("%d schedule conflict(s) in strict mode", len(project.Conflicts))
+	}
+
+	for _, out := range outputs {
+		output, err := renderer.Render(out.format, project, cfg.opts)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error rendering HTML: %v\n", err)
-			os.Exit(1)
+			return fmt.Errorf("rendering %s: %w", out.format, err)
 		}
-	case "confluence":
-		output, err = renderer.RenderConfluence(project)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error rendering Confluence: %v\n", err)
-			os.Exit(1)
+
+		// Write output file or stdout
+		if err := writeOutput(out.path, []byte(output)); err != nil {
+			return err
 		}
-	default: // svg
-		output, err = renderer.RenderSVG(project)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error rendering SVG: %v\n", err)
-			os.Exit(1)
+		if out.path != "-" && !jsonDiagnostics {
+			fmt.Fprintf(os.Stderr, "✓ Generated Gantt chart (%s): %s\n", out.format, out.path)
 		}
 	}
+	return nil
+}
 
-	// Write output file or stdout
-	if outputPath == "-" {
-		if _, err := os.Stdout.WriteString(output); err != nil {
-			fmt.Fprintf(os.Stderr, "Error writing to stdout: %v\n", err)
-			os.Exit(1)
+// readInput reads the plan from a file, or from stdin when path is "-"
+func readInput(path string) ([]byte, error) {
+	if path == "-" {
+		input, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return nil, fmt.Errorf("reading from stdin: %w", err)
 		}
-	} else {
-		if err := os.WriteFile(outputPath, []byte(output), 0644); err != nil {
-			fmt.Fprintf(os.Stderr, "Error writing output file: %v\n", err)
-			os.Exit(1)
+		return input, nil
+	}
+
+	input, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading input file: %w", err)
+	}
+	return input, nil
+}
+
+// writeOutput writes to a file, or to stdout when path is "-"
+func writeOutput(path string, data []byte) error {
+	if path == "-" {
+		if _, err := os.Stdout.Write(data); err != nil {
+			return fmt.Errorf("writing to stdout: %w", err)
 		}
-		fmt.Fprintf(os.Stderr, "✓ Generated Gantt chart (%s): %s\n", outputFormat, outputPath)
+		return nil
 	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("writing output file: %w", err)
+	}
+	return nil
+}
+
+// loadProject reads, parses, validates and resolves a plan. Problems found in
+// the plan are returned together as a planError.
+func loadProject(path string, opts resolver.Options) (*model.Project, error) {
+	input, err := readInput(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse markdown
+	project, err := parser.Parse(input)
+	if err != nil {
+		return nil, inPlan(path, nil, fmt.Errorf("parsing markdown: %w", err))
+	}
+
+	// Validate project structure
+	if err := project.Validate(); err != nil {
+		return nil, inPlan(path, project, fmt.Errorf("validation: %w", err))
+	}
+
+	// Resolve dependencies and calculate dates
+	if err := resolver.ResolveWithOptions(project, opts); err != nil {
+		return nil, inPlan(path, project, fmt.Errorf("resolving dependencies: %w", err))
+	}
+
+	return project, nil
 }
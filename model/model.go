@@ -2,6 +2,7 @@ package model
 
 import (
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -15,10 +16,27 @@ const (
 	StartToFinish  DependencyType = "start-to-finish"
 )
 
+// Position is a 1-based line and column in the markdown source
+type Position struct {
+	Line   int
+	Column int
+}
+
+// IsValid returns true if the position was recorded by the parser
+func (p Position) IsValid() bool {
+	return p.Line > 0
+}
+
+func (p Position) String() string {
+	return fmt.Sprintf("%d:%d", p.Line, p.Column)
+}
+
 // Dependency represents a task dependency
 type Dependency struct {
 	TaskName string
 	Type     DependencyType
+	Lag      int      // Business days between the linked dates (negative for lead time)
+	Pos      Position // Row in the dependency table
 }
 
 // Task represents a task or milestone
@@ -30,13 +48,33 @@ type Task struct {
 	End          *time.Time // Explicit end date (only for date ranges)
 	Date         *time.Time // Explicit date for milestones
 	Duration     int        // Duration in days
+	Progress     int        // Percent complete (0-100)
 	Link         string
 	CalendarName string
+	Resources    []string // Assigned people or teams
+	Priority     int      // Higher priority tasks keep their dates when leveling resources
 	Dependencies []Dependency
 
+	// Scheduling constraints
+	NotBefore    *time.Time // Start no earlier than
+	Deadline     *time.Time // Finish no later than; flagged but never moves the task
+	MustFinishOn *time.Time // Required finish date
+
 	// Calculated fields (filled by resolver)
 	CalculatedStart *time.Time
 	CalculatedEnd   *time.Time
+
+	// Critical path analysis (filled by resolver)
+	LateStart  *time.Time
+	LateEnd    *time.Time
+	TotalFloat int // Business days the task can slip without delaying the project
+	FreeFloat  int // Business days the task can slip without delaying any successor
+	IsCritical bool
+
+	IsSummary bool // Dates rolled up from child headings (filled by resolver)
+
+	Pos         Position            // Heading or milestone in the source
+	PropertyPos map[string]Position // Property table row of each property, keyed by property name
 }
 
 // IsCalculated returns true if the task timing is determined by dependencies
@@ -44,12 +82,31 @@ func (t *Task) IsCalculated() bool {
 	return t.Start == nil && t.Date == nil && len(t.Dependencies) > 0
 }
 
+// IsOverdue returns true if the task should have finished before statusDate but is not complete
+func (t *Task) IsOverdue(statusDate time.Time) bool {
+	return t.CalculatedEnd != nil && t.CalculatedEnd.Before(statusDate) && t.Progress < 100
+}
+
+// PropertyPosition returns where a property was set, falling back to the task itself
+func (t *Task) PropertyPosition(key string) Position {
+	if pos, ok := t.PropertyPos[key]; ok {
+		return pos
+	}
+	return t.Pos
+}
+
+// MissesDeadline returns true if the task is scheduled to finish after its deadline
+func (t *Task) MissesDeadline() bool {
+	return t.Deadline != nil && t.CalculatedEnd != nil && t.CalculatedEnd.After(*t.Deadline)
+}
+
 // Calendar represents working days configuration
 type Calendar struct {
 	Name      string
 	IsDefault bool
 	Weekends  []time.Weekday
 	Holidays  []time.Time
+	Pos       Position // Calendar heading in the source
 }
 
 // Project represents the entire parsed document
@@ -57,6 +114,169 @@ type Project struct {
 	Name      string
 	Tasks     []Task
 	Calendars []Calendar
+	Warnings  []Warning
+	Conflicts []Conflict
+}
+
+// Warning is a non-fatal problem found while processing a project
+type Warning struct {
+	Task    string // Task the warning is about, if any
+	Code    string // One of the Code constants
+	Message string
+	Pos     Position
+}
+
+// Conflict is a scheduling constraint the resolver could not satisfy
+type Conflict struct {
+	Tasks   []string // The conflicting task first, then the tasks imposing the constraint
+	Message string
+	Pos     Position // Position of the conflicting task
+}
+
+// Diagnostic returns the warning as a warning-severity Diagnostic
+func (w Warning) Diagnostic() Diagnostic {
+	return Diagnostic{Severity: SeverityWarning, Code: w.Code, Pos: w.Pos, Task: w.Task, Message: w.Message}
+}
+
+// Diagnostic returns the conflict as a warning-severity Diagnostic
+func (c Conflict) Diagnostic() Diagnostic {
+	var task string
+	if len(c.Tasks) > 0 {
+		task = c.Tasks[0]
+	}
+	return Diagnostic{Severity: SeverityWarning, Code: CodeScheduleConflict, Pos: c.Pos, Task: task, Message: c.Message}
+}
+
+// Severity says whether a diagnostic stops the plan from being charted
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Diagnostic codes
+const (
+	CodeTooManyTasks           = "too-many-tasks"
+	CodeEmptyTaskName          = "empty-task-name"
+	CodeTaskNameTooLong        = "task-name-too-long"
+	CodeDuplicateTask          = "duplicate-task"
+	CodeUnknownDependency      = "unknown-dependency"
+	CodeUnknownCalendar        = "unknown-calendar"
+	CodeCircularDependency     = "circular-dependency"
+	CodeUnresolvedDependencies = "unresolved-dependencies"
+	CodeNoTiming               = "no-timing"
+	CodeSummaryMismatch        = "summary-mismatch"
+	CodeScheduleConflict       = "schedule-conflict"
+	CodeMissedDeadline         = "missed-deadline"
+	CodeIgnoredConstraint      = "ignored-constraint"
+
+	// Input the parser or resolver ignored
+	CodeInvalidDate           = "invalid-date"
+	CodeInvalidDuration       = "invalid-duration"
+	CodeInvalidValue          = "invalid-value"
+	CodeUnknownProperty       = "unknown-property"
+	CodeUnknownDependencyType = "unknown-dependency-type"
+	CodeUnknownWeekday        = "unknown-weekday"
+	CodeOrphanTable           = "orphan-table"
+)
+
+// Diagnostic is a problem found in a plan, tied to a place in the source
+type Diagnostic struct {
+	Severity Severity
+	Code     string // Stable identifier, one of the Code constants
+	Pos      Position
+	Task     string // Task the diagnostic is about, if any
+	Message  string
+}
+
+// Errorf returns an error-severity Diagnostic
+func Errorf(code string, pos Position, task string, format string, args ...interface{}) Diagnostic {
+	return Diagnostic{Severity: SeverityError, Code: code, Pos: pos, Task: task, Message: fmt.Sprintf(format, args...)}
+}
+
+func (d Diagnostic) Error() string {
+	return d.Message
+}
+
+// Diagnostics is a list of problems; as an error it describes all of them
+type Diagnostics []Diagnostic
+
+func (d Diagnostics) Error() string {
+	messages := make([]string, len(d))
+	for i, diag := range d {
+		messages[i] = diag.Message
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Err returns d as an error, or nil if it is empty
+func (d Diagnostics) Err() error {
+	if len(d) == 0 {
+		return nil
+	}
+	return d
+}
+
+// ParentIndex returns the index of the heading a task is nested under, or -1 for
+// top-level headings and milestones
+func (p *Project) ParentIndex(index int) int {
+	task := p.Tasks[index]
+	if task.IsMilestone {
+		return -1
+	}
+
+	for j := index - 1; j >= 0; j-- {
+		if !p.Tasks[j].IsMilestone && p.Tasks[j].Level < task.Level {
+			return j
+		}
+	}
+	return -1
+}
+
+// ChildIndices returns the indices of the headings directly nested under the task at index
+func (p *Project) ChildIndices(index int) []int {
+	parent := p.Tasks[index]
+	if parent.IsMilestone {
+		return nil
+	}
+
+	var children []int
+	for j := index + 1; j < len(p.Tasks); j++ {
+		if p.Tasks[j].IsMilestone {
+			continue
+		}
+		if p.Tasks[j].Level <= parent.Level {
+			break
+		}
+		if p.ParentIndex(j) == index {
+			children = append(children, j)
+		}
+	}
+	return children
+}
+
+// DefaultCalendar returns the calendar marked as default, or nil if there is none
+func (p *Project) DefaultCalendar() *Calendar {
+	for i := range p.Calendars {
+		if p.Calendars[i].IsDefault {
+			return &p.Calendars[i]
+		}
+	}
+	return nil
+}
+
+// CalendarFor returns the calendar a task is scheduled with: its named calendar,
+// else the project default (nil means the built-in weekday calendar)
+func (p *Project) CalendarFor(task *Task) *Calendar {
+	if task.CalendarName != "" {
+		for i := range p.Calendars {
+			if p.Calendars[i].Name == task.CalendarName {
+				return &p.Calendars[i]
+			}
+		}
+	}
+	return p.DefaultCalendar()
 }
 
 const (
@@ -64,25 +284,30 @@ const (
 	MaxTasks          = 1000
 )
 
-// Validate checks the project for common errors and invariants
+// Validate checks the project for common errors and invariants. It returns
+// every problem found as Diagnostics, or nil if there are none.
 func (p *Project) Validate() error {
+	var diags Diagnostics
+
 	if len(p.Tasks) > MaxTasks {
-		return fmt.Errorf("too many tasks: %d (max %d)", len(p.Tasks), MaxTasks)
+		diags = append(diags, Errorf(CodeTooManyTasks, Position{}, "", "too many tasks: %d (max %d)", len(p.Tasks), MaxTasks))
 	}
 
 	// Build task name set for uniqueness and dependency checking
 	taskNames := make(map[string]bool)
 	for _, task := range p.Tasks {
 		if task.Name == "" {
-			return fmt.Errorf("task has empty name")
+			diags = append(diags, Errorf(CodeEmptyTaskName, task.Pos, "", "task has empty name"))
+			continue
 		}
 
 		if len(task.Name) > MaxTaskNameLength {
-			return fmt.Errorf("task name exceeds %d characters: %q", MaxTaskNameLength, truncate(task.Name, 50))
+			diags = append(diags, Errorf(CodeTaskNameTooLong, task.Pos, task.Name,
+				"task name exceeds %d characters: %q", MaxTaskNameLength, truncate(task.Name, 50)))
 		}
 
 		if taskNames[task.Name] {
-			return fmt.Errorf("duplicate task name: %s", task.Name)
+			diags = append(diags, Errorf(CodeDuplicateTask, task.Pos, task.Name, "duplicate task name: %s", task.Name))
 		}
 		taskNames[task.Name] = true
 	}
@@ -97,16 +322,18 @@ func (p *Project) Validate() error {
 	for _, task := range p.Tasks {
 		for _, dep := range task.Dependencies {
 			if !taskNames[dep.TaskName] {
-				return fmt.Errorf("task %q depends on non-existent task: %s", task.Name, dep.TaskName)
+				diags = append(diags, Errorf(CodeUnknownDependency, dep.Pos, task.Name,
+					"task %q depends on non-existent task: %s", task.Name, dep.TaskName))
 			}
 		}
 
 		if task.CalendarName != "" && !calNames[task.CalendarName] {
-			return fmt.Errorf("task %q references unknown calendar: %s", task.Name, task.CalendarName)
+			diags = append(diags, Errorf(CodeUnknownCalendar, task.PropertyPosition("Calendar"), task.Name,
+				"task %q references unknown calendar: %s", task.Name, task.CalendarName))
 		}
 	}
 
-	return nil
+	return diags.Err()
 }
 
 func truncate(s string, maxLen int) string {
@@ -1,6 +1,8 @@
 package model
 
 import (
+	"errors"
+	"fmt"
 	"testing"
 	"time"
 )
@@ -32,6 +34,136 @@ func TestTask_IsCalculated(t *testing.T) {
 	}
 }
 
+func TestTask_IsOverdue(t *testing.T) {
+	statusDate := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		task Task
+		want bool
+	}{
+		{
+			name: "unfinished task ended before status date",
+			task: Task{CalculatedEnd: ptr(time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)), Progress: 60},
+			want: true,
+		},
+		{
+			name: "completed task ended before status date",
+			task: Task{CalculatedEnd: ptr(time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)), Progress: 100},
+			want: false,
+		},
+		{
+			name: "task ending on status date",
+			task: Task{CalculatedEnd: ptr(statusDate)},
+			want: false,
+		},
+		{
+			name: "unresolved task",
+			task: Task{},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.task.IsOverdue(statusDate); got != tt.want {
+				t.Errorf("Task.IsOverdue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTask_MissesDeadline(t *testing.T) {
+	deadline := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		task Task
+		want bool
+	}{
+		{
+			name: "finishes after deadline",
+			task: Task{Deadline: &deadline, CalculatedEnd: ptr(time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC))},
+			want: true,
+		},
+		{
+			name: "finishes on deadline",
+			task: Task{Deadline: &deadline, CalculatedEnd: ptr(deadline)},
+			want: false,
+		},
+		{
+			name: "no deadline",
+			task: Task{CalculatedEnd: ptr(time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC))},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.task.MissesDeadline(); got != tt.want {
+				t.Errorf("Task.MissesDeadline() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProject_CalendarFor(t *testing.T) {
+	project := Project{
+		Calendars: []Calendar{
+			{Name: "US", IsDefault: true},
+			{Name: "UK"},
+		},
+	}
+
+	if got := project.CalendarFor(&Task{CalendarName: "UK"}); got == nil || got.Name != "UK" {
+		t.Errorf("CalendarFor(UK) = %v, want UK calendar", got)
+	}
+	if got := project.CalendarFor(&Task{}); got == nil || got.Name != "US" {
+		t.Errorf("CalendarFor() = %v, want default US calendar", got)
+	}
+
+	empty := Project{}
+	if got := empty.CalendarFor(&Task{}); got != nil {
+		t.Errorf("CalendarFor() without calendars = %v, want nil", got)
+	}
+}
+
+func TestProject_ChildIndices(t *testing.T) {
+	project := Project{
+		Tasks: []Task{
+			{Name: "Phase 1", Level: 2},
+			{Name: "Design", Level: 3},
+			{Name: "Mockups", Level: 4},
+			{Name: "Review", IsMilestone: true},
+			{Name: "Build", Level: 3},
+			{Name: "Phase 2", Level: 2},
+		},
+	}
+
+	tests := []struct {
+		index      int
+		wantParent int
+		wantKids   []int
+	}{
+		{0, -1, []int{1, 4}},
+		{1, 0, []int{2}},
+		{2, 1, nil},
+		{3, -1, nil}, // Milestones are never nested
+		{4, 0, nil},
+		{5, -1, nil},
+	}
+
+	for _, tt := range tests {
+		name := project.Tasks[tt.index].Name
+		if got := project.ParentIndex(tt.index); got != tt.wantParent {
+			t.Errorf("ParentIndex(%s) = %d, want %d", name, got, tt.wantParent)
+		}
+		if got := project.ChildIndices(tt.index); fmt.Sprint(got) != fmt.Sprint(tt.wantKids) {
+			t.Errorf("ChildIndices(%s) = %v, want %v", name, got, tt.wantKids)
+		}
+	}
+}
+
 func TestProject_Validate(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -128,6 +260,39 @@ func TestProject_Validate(t *testing.T) {
 	}
 }
 
+func TestProject_ValidateCollectsAllErrors(t *testing.T) {
+	project := Project{
+		Tasks: []Task{
+			{Name: "Task A", Level: 2, Pos: Position{Line: 3, Column: 4}},
+			{Name: "Task A", Level: 2, Pos: Position{Line: 5, Column: 4}},
+			{Name: "Task B", Level: 2, Dependencies: []Dependency{
+				{TaskName: "Task C", Type: FinishToStart, Pos: Position{Line: 9, Column: 3}},
+			}},
+		},
+	}
+
+	var diags Diagnostics
+	if err := project.Validate(); !errors.As(err, &diags) {
+		t.Fatalf("Validate() error = %v, want Diagnostics", err)
+	}
+
+	want := []struct {
+		code string
+		line int
+	}{
+		{CodeDuplicateTask, 5},
+		{CodeUnknownDependency, 9},
+	}
+	if len(diags) != len(want) {
+		t.Fatalf("Validate() = %d diagnostics, want %d: %v", len(diags), len(want), diags)
+	}
+	for i, w := range want {
+		if diags[i].Code != w.code || diags[i].Pos.Line != w.line || diags[i].Severity != SeverityError {
+			t.Errorf("diagnostic[%d] = %+v, want %s error on line %d", i, diags[i], w.code, w.line)
+		}
+	}
+}
+
 func ptr(t time.Time) *time.Time {
 	return &t
 }
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"gantt-gen/renderer"
+)
+
+// outputSpec is one file to write and the format to render it in
+type outputSpec struct {
+	path   string
+	format string
+}
+
+// formatFlag is --format; it remembers whether it was given so later -o flags can use it
+type formatFlag struct {
+	value    string
+	explicit bool
+}
+
+func (f *formatFlag) String() string {
+	return f.value
+}
+
+func (f *formatFlag) Set(value string) error {
+	format, ok := renderer.Lookup(value)
+	if !ok {
+		return fmt.Errorf("invalid format '%s'. Use one of: %s", value, strings.Join(renderer.FormatNames(), ", "))
+	}
+	f.value = format.Name
+	f.explicit = true
+	return nil
+}
+
+// outputsFlag is the repeatable -o flag. Each output uses the --format given
+// before it on the command line, or else the format implied by its extension.
+type outputsFlag struct {
+	format *formatFlag
+	specs  []outputSpec
+}
+
+func (o *outputsFlag) String() string {
+	paths := make([]string, len(o.specs))
+	for i, spec := range o.specs {
+		paths[i] = spec.path
+	}
+	return strings.Join(paths, ",")
+}
+
+func (o *outputsFlag) Set(path string) error {
+	spec, err := resolveOutput(path, o.format)
+	if err != nil {
+		return err
+	}
+	o.specs = append(o.specs, spec)
+	return nil
+}
+
+// resolveOutput picks the format for an output path
+func resolveOutput(path string, format *formatFlag) (outputSpec, error) {
+	if format.explicit {
+		return outputSpec{path: path, format: format.value}, nil
+	}
+	if inferred, ok := renderer.FormatForPath(path); ok {
+		return outputSpec{path: path, format: inferred.Name}, nil
+	}
+	if path == "-" {
+		return outputSpec{path: path, format: format.value}, nil
+	}
+	return outputSpec{}, fmt.Errorf("cannot infer format of '%s' from its extension; pass --format before it", path)
+}
+
+// positionalOutputs adds the optional positional output after the input file
+// to the -o outputs. Flag parsing stops at the input file, so anything after it
+// that looks like a flag is rejected rather than taken as a file name.
+func positionalOutputs(args []string, outputs *outputsFlag) ([]outputSpec, error) {
+	if len(args) > 2 || (len(args) == 2 && strings.HasPrefix(args[1], "-") && args[1] != "-") {
+		return nil, fmt.Errorf("unexpected '%s' after the input file; flags must come before the input file", strings.Join(args[1:], " "))
+	}
+
+	specs := outputs.specs
+	if len(args) == 2 {
+		// Positional output uses --format, else its extension, else SVG
+		spec := outputSpec{path: args[1], format: outputs.format.value}
+		if inferred, ok := renderer.FormatForPath(args[1]); ok && !outputs.format.explicit {
+			spec.format = inferred.Name
+		}
+		specs = append(specs, spec)
+	}
+	return specs, nil
+}
+
+// printFormats lists the registered output formats for --help
+func printFormats() {
+	fmt.Fprintf(os.Stderr, "\nFormats:\n")
+	for _, format := range renderer.Formats() {
+		fmt.Fprintf(os.Stderr, "  %-18s %s (%s)\n", format.Name, format.Description, strings.Join(format.Extensions, ", "))
+	}
+}
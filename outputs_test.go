@@ -0,0 +1,84 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestOutputsFlag(t *testing.T) {
+	parse := func(args ...string) ([]outputSpec, error) {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+		format := &formatFlag{value: "svg"}
+		fs.Var(format, "format", "")
+		outputs := &outputsFlag{format: format}
+		fs.Var(outputs, "o", "")
+		err := fs.Parse(args)
+		return outputs.specs, err
+	}
+
+	specs, err := parse("-o", "a.svg", "-o", "b.html", "--format=confluence", "-o", "c.out", "-o", "-")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	want := []outputSpec{{"a.svg", "svg"}, {"b.html", "html"}, {"c.out", "confluence"}, {"-", "confluence"}}
+	if fmt.Sprint(specs) != fmt.Sprint(want) {
+		t.Errorf("outputs = %v, want %v", specs, want)
+	}
+
+	if _, err := parse("-o", "chart.png"); err == nil {
+		t.Error("expected error for an output with an unknown extension")
+	}
+	if _, err := parse("--format=pdf"); err == nil {
+		t.Error("expected error for an unknown format")
+	}
+}
+
+func TestPositionalOutputs(t *testing.T) {
+	parse := func(args ...string) ([]outputSpec, error) {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+		format := &formatFlag{value: "svg"}
+		fs.Var(format, "format", "")
+		outputs := &outputsFlag{format: format}
+		fs.Var(outputs, "o", "")
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		return positionalOutputs(fs.Args(), outputs)
+	}
+
+	tests := []struct {
+		args []string
+		want []outputSpec
+	}{
+		{[]string{"plan.md", "out.html"}, []outputSpec{{"out.html", "html"}}},
+		{[]string{"plan.md", "-"}, []outputSpec{{"-", "svg"}}},
+		{[]string{"--format=confluence", "plan.md", "-"}, []outputSpec{{"-", "confluence"}}},
+		{[]string{"-o", "a.svg", "plan.md"}, []outputSpec{{"a.svg", "svg"}}},
+	}
+	for _, tt := range tests {
+		specs, err := parse(tt.args...)
+		if err != nil {
+			t.Errorf("%v: error = %v", tt.args, err)
+			continue
+		}
+		if fmt.Sprint(specs) != fmt.Sprint(tt.want) {
+			t.Errorf("%v: outputs = %v, want %v", tt.args, specs, tt.want)
+		}
+	}
+
+	// Flags after the input file are not parsed, so they must not become outputs
+	for _, args := range [][]string{
+		{"plan.md", "-o", "a.svg", "-o", "b.html"},
+		{"plan.md", "--format=html"},
+		{"plan.md", "out.svg", "extra.svg"},
+	} {
+		if _, err := parse(args...); err == nil || !strings.Contains(err.Error(), "flags must come before the input file") {
+			t.Errorf("%v: error = %v, want flags-before-input error", args, err)
+		}
+	}
+}
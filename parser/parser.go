@@ -2,6 +2,8 @@ package parser
 
 import (
 	"bytes"
+	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -30,6 +32,13 @@ type parseContext struct {
 	currentTaskIndex     int // Changed from *model.Task
 	currentCalendarIndex int // Changed from *model.Calendar
 	tableCtx             *tableContext
+	lineStarts           []int // Byte offset of each source line, for positions
+}
+
+// tableRow is one data row of a table and where it starts in the source
+type tableRow struct {
+	cells []string
+	pos   model.Position
 }
 
 // Parse parses markdown and returns a Project
@@ -43,6 +52,7 @@ func Parse(source []byte) (*model.Project, error) {
 		project:              &model.Project{},
 		currentTaskIndex:     -1,
 		currentCalendarIndex: -1,
+		lineStarts:           lineStarts(source),
 	}
 
 	// Walk the AST
@@ -64,6 +74,7 @@ func Parse(source []byte) (*model.Project, error) {
 				calName := strings.TrimSpace(strings.TrimPrefix(text, "Calendar:"))
 				cal := model.Calendar{
 					Name: calName,
+					Pos:  ctx.blockPosition(node),
 				}
 				ctx.project.Calendars = append(ctx.project.Calendars, cal)
 				ctx.currentCalendarIndex = len(ctx.project.Calendars) - 1
@@ -72,6 +83,7 @@ func Parse(source []byte) (*model.Project, error) {
 				task := model.Task{
 					Name:  text,
 					Level: node.Level,
+					Pos:   ctx.blockPosition(node),
 				}
 				ctx.project.Tasks = append(ctx.project.Tasks, task)
 				ctx.currentTaskIndex = len(ctx.project.Tasks) - 1
@@ -87,6 +99,7 @@ func Parse(source []byte) (*model.Project, error) {
 						Name:        text,
 						IsMilestone: true,
 						Level:       0,
+						Pos:         ctx.blockPosition(node),
 					}
 					ctx.project.Tasks = append(ctx.project.Tasks, task)
 					ctx.currentTaskIndex = len(ctx.project.Tasks) - 1
@@ -118,9 +131,37 @@ func (ctx *parseContext) currentCalendar() *model.Calendar {
 	return nil
 }
 
+// lineStarts returns the byte offset at which each line of source begins
+func lineStarts(source []byte) []int {
+	starts := []int{0}
+	for i, b := range source {
+		if b == '\n' {
+			starts = append(starts, i+1)
+		}
+	}
+	return starts
+}
+
+// position converts a byte offset in the source to a line and column
+func (ctx *parseContext) position(offset int) model.Position {
+	line := sort.Search(len(ctx.lineStarts), func(i int) bool {
+		return ctx.lineStarts[i] > offset
+	})
+	return model.Position{Line: line, Column: offset - ctx.lineStarts[line-1] + 1}
+}
+
+// blockPosition returns where a block node's first line of content starts
+func (ctx *parseContext) blockPosition(n ast.Node) model.Position {
+	if lines := n.Lines(); lines.Len() > 0 {
+		return ctx.position(lines.At(0).Start)
+	}
+	return model.Position{}
+}
+
 func handleTable(table *gast.Table, source []byte, ctx *parseContext) {
 	var headers []string
-	var rows [][]string
+	var rows []tableRow
+	var pos model.Position
 
 	// Extract table data from TableHeader and TableRow nodes
 	for child := table.FirstChild(); child != nil; child = child.NextSibling() {
@@ -131,6 +172,9 @@ func handleTable(table *gast.Table, source []byte, ctx *parseContext) {
 				text := extractText(cell, source)
 				headers = append(headers, strings.TrimSpace(text))
 			}
+			if first := node.FirstChild(); first != nil {
+				pos = ctx.blockPosition(first)
+			}
 		case *gast.TableRow:
 			// Extract data row cells
 			var cells []string
@@ -138,66 +182,178 @@ func handleTable(table *gast.Table, source []byte, ctx *parseContext) {
 				text := extractText(cell, source)
 				cells = append(cells, strings.TrimSpace(text))
 			}
-			rows = append(rows, cells)
+			var rowPos model.Position
+			if first := node.FirstChild(); first != nil {
+				rowPos = ctx.blockPosition(first)
+			}
+			rows = append(rows, tableRow{cells: cells, pos: rowPos})
 		}
 	}
 
 	// Determine table type and process
 	if len(headers) >= 2 {
 		if headers[0] == "Property" && headers[1] == "Value" {
+			if ctx.currentTask() == nil {
+				ctx.warn(model.CodeOrphanTable, pos, "", "property table is not under a task heading; ignored")
+				return
+			}
 			parsePropertyTable(rows, ctx)
 		} else if headers[0] == "Depends On" && headers[1] == "Type" {
-			parseDependencyTable(rows, ctx)
+			if ctx.currentTask() == nil {
+				ctx.warn(model.CodeOrphanTable, pos, "", "dependency table is not under a task heading; ignored")
+				return
+			}
+			parseDependencyTable(headers, rows, ctx)
 		} else if headers[0] == "Type" && headers[1] == "Value" {
+			if ctx.currentCalendar() == nil {
+				ctx.warn(model.CodeOrphanTable, pos, "", "calendar table is not under a Calendar: heading; ignored")
+				return
+			}
 			parseCalendarTable(rows, ctx)
 		}
 	}
 }
 
-func parsePropertyTable(rows [][]string, ctx *parseContext) {
+// warn records input that was ignored or could not be understood
+func (ctx *parseContext) warn(code string, pos model.Position, task string, format string, args ...interface{}) {
+	ctx.project.Warnings = append(ctx.project.Warnings, model.Warning{
+		Task:    task,
+		Code:    code,
+		Message: fmt.Sprintf(format, args...),
+		Pos:     pos,
+	})
+}
+
+// parseDate parses the value of a table row, warning when it is not a date
+func (ctx *parseContext) parseDate(row tableRow, task string) (time.Time, bool) {
+	t, err := dateparse.ParseAny(row.cells[1])
+	if err != nil {
+		ctx.warn(model.CodeInvalidDate, row.pos, task, "%s %q is not a date; ignored", row.cells[0], row.cells[1])
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+func parsePropertyTable(rows []tableRow, ctx *parseContext) {
 	task := ctx.currentTask()
 	if task == nil {
 		return
 	}
 
 	for _, row := range rows {
-		if len(row) < 2 {
+		if len(row.cells) < 2 {
 			continue
 		}
 
-		key := row[0]
-		value := row[1]
+		key := row.cells[0]
+		value := row.cells[1]
+
+		if task.PropertyPos == nil {
+			task.PropertyPos = make(map[string]model.Position)
+		}
+		task.PropertyPos[key] = row.pos
+
+		if !knownProperties[key] {
+			ctx.warn(model.CodeUnknownProperty, row.pos, task.Name, "unknown property %q; ignored", key)
+			continue
+		}
+		if value == "" {
+			continue
+		}
 
 		switch key {
 		case "Start":
-			if t, err := dateparse.ParseAny(value); err == nil {
+			if t, ok := ctx.parseDate(row, task.Name); ok {
 				task.Start = &t
 			}
 		case "End":
-			if t, err := dateparse.ParseAny(value); err == nil {
+			if t, ok := ctx.parseDate(row, task.Name); ok {
 				task.End = &t
 			}
 		case "Date":
-			if t, err := dateparse.ParseAny(value); err == nil {
+			if t, ok := ctx.parseDate(row, task.Name); ok {
 				task.Date = &t
 			}
+		case "Not Before", "Start No Earlier Than":
+			if t, ok := ctx.parseDate(row, task.Name); ok {
+				task.NotBefore = &t
+			}
+		case "Deadline", "Finish No Later Than":
+			if t, ok := ctx.parseDate(row, task.Name); ok {
+				task.Deadline = &t
+			}
+		case "Must Finish On":
+			if t, ok := ctx.parseDate(row, task.Name); ok {
+				task.MustFinishOn = &t
+			}
 		case "Duration":
-			task.Duration = parseDuration(value)
+			days, ok := parseDuration(value)
+			switch {
+			case !ok:
+				ctx.warn(model.CodeInvalidDuration, row.pos, task.Name,
+					"Duration %q is not a duration like 5d, 2w or 1m; ignored", value)
+			case days < 0:
+				ctx.warn(model.CodeInvalidDuration, row.pos, task.Name, "Duration %q is negative; ignored", value)
+			default:
+				task.Duration = days
+			}
+		case "Progress":
+			if percent, ok := parseProgress(value); ok {
+				task.Progress = percent
+			} else {
+				ctx.warn(model.CodeInvalidValue, row.pos, task.Name, "Progress %q is not a percentage; ignored", value)
+			}
 		case "Link":
 			task.Link = value
 		case "Calendar":
 			task.CalendarName = value
+		case "Assignee", "Resources":
+			task.Resources = parseResources(value)
+		case "Priority":
+			if n, err := strconv.Atoi(value); err == nil {
+				task.Priority = n
+			} else {
+				ctx.warn(model.CodeInvalidValue, row.pos, task.Name, "Priority %q is not a whole number; ignored", value)
+			}
 		}
 	}
 }
 
-func parseDependencyTable(rows [][]string, ctx *parseContext) {
+// knownProperties are the keys a property table understands
+var knownProperties = map[string]bool{
+	"Start":                 true,
+	"End":                   true,
+	"Date":                  true,
+	"Not Before":            true,
+	"Start No Earlier Than": true,
+	"Deadline":              true,
+	"Finish No Later Than":  true,
+	"Must Finish On":        true,
+	"Duration":              true,
+	"Progress":              true,
+	"Link":                  true,
+	"Calendar":              true,
+	"Assignee":              true,
+	"Resources":             true,
+	"Priority":              true,
+}
+
+func parseDependencyTable(headers []string, rows []tableRow, ctx *parseContext) {
 	task := ctx.currentTask()
 	if task == nil {
 		return
 	}
 
-	for _, row := range rows {
+	// Optional Lag column (e.g. "3d", "-2d", "1w")
+	lagCol := -1
+	for i, header := range headers {
+		if header == "Lag" {
+			lagCol = i
+		}
+	}
+
+	for _, entry := range rows {
+		row := entry.cells
 		if len(row) < 1 || row[0] == "-" {
 			continue
 		}
@@ -210,41 +366,77 @@ func parseDependencyTable(rows [][]string, ctx *parseContext) {
 		dep := model.Dependency{
 			TaskName: row[0],
 			Type:     depType,
+			Pos:      entry.pos,
+		}
+		if lagCol >= 0 && lagCol < len(row) && row[lagCol] != "" {
+			if lag, ok := parseDuration(row[lagCol]); ok {
+				dep.Lag = lag
+			} else {
+				ctx.warn(model.CodeInvalidDuration, entry.pos, task.Name,
+					"Lag %q is not a duration like 3d, -2d or 1w; ignored", row[lagCol])
+			}
 		}
 		task.Dependencies = append(task.Dependencies, dep)
 	}
 }
 
-func parseCalendarTable(rows [][]string, ctx *parseContext) {
+func parseCalendarTable(rows []tableRow, ctx *parseContext) {
 	cal := ctx.currentCalendar()
 	if cal == nil {
 		return
 	}
 
 	for _, row := range rows {
-		if len(row) < 2 {
+		if len(row.cells) < 2 {
 			continue
 		}
 
-		key := row[0]
-		value := row[1]
+		key := row.cells[0]
+		value := row.cells[1]
 
 		switch key {
 		case "Default":
-			cal.IsDefault = strings.ToLower(value) == "true"
+			switch strings.ToLower(value) {
+			case "true":
+				cal.IsDefault = true
+			case "false":
+				cal.IsDefault = false
+			default:
+				ctx.warn(model.CodeInvalidValue, row.pos, "", "calendar %q: Default %q is not true or false; ignored", cal.Name, value)
+			}
 		case "Weekends":
-			cal.Weekends = parseWeekends(value)
+			weekends, unknown := parseWeekends(value)
+			cal.Weekends = weekends
+			for _, name := range unknown {
+				ctx.warn(model.CodeUnknownWeekday, row.pos, "", "calendar %q: %q is not a day of the week; ignored", cal.Name, name)
+			}
 		case "Holiday":
-			if t, err := dateparse.ParseAny(value); err == nil {
+			if t, ok := ctx.parseDate(row, ""); ok {
 				cal.Holidays = append(cal.Holidays, t)
 			}
+		default:
+			ctx.warn(model.CodeUnknownProperty, row.pos, "", "calendar %q: unknown setting %q; ignored", cal.Name, key)
+		}
+	}
+}
+
+// parseResources splits a comma-separated list of assignees
+func parseResources(s string) []string {
+	var resources []string
+	for _, part := range strings.Split(s, ",") {
+		if name := strings.TrimSpace(part); name != "" {
+			resources = append(resources, name)
 		}
 	}
+	return resources
 }
 
-func parseWeekends(s string) []time.Weekday {
+// parseWeekends parses a comma-separated list of day names, returning the
+// names it did not recognize separately
+func parseWeekends(s string) ([]time.Weekday, []string) {
 	parts := strings.Split(s, ",")
 	var weekends []time.Weekday
+	var unknown []string
 
 	dayMap := map[string]time.Weekday{
 		"sun": time.Sunday,
@@ -257,41 +449,67 @@ func parseWeekends(s string) []time.Weekday {
 	}
 
 	for _, part := range parts {
-		day := strings.ToLower(strings.TrimSpace(part))
+		name := strings.TrimSpace(part)
+		if name == "" {
+			continue
+		}
+		day := strings.ToLower(name)
 		if len(day) > 3 {
 			day = day[:3]
 		}
 		if wd, ok := dayMap[day]; ok {
 			weekends = append(weekends, wd)
+		} else {
+			unknown = append(unknown, name)
 		}
 	}
 
-	return weekends
+	return weekends, unknown
 }
 
-func parseDuration(s string) int {
+// parseDuration parses a whole number of days, weeks or months ("5d", "2w",
+// "1m") into business days. It reports false for anything else.
+func parseDuration(s string) (int, bool) {
 	s = strings.TrimSpace(s)
 	if len(s) < 2 {
-		return 0
+		return 0, false
 	}
 
 	unit := s[len(s)-1]
 	numStr := s[:len(s)-1]
 	num, err := strconv.Atoi(numStr)
 	if err != nil {
-		return 0
+		return 0, false
 	}
 
 	switch unit {
 	case 'd':
-		return num
+		return num, true
 	case 'w':
-		return num * 5 // 5 business days per week
+		return num * 5, true // 5 business days per week
 	case 'm':
-		return num * 20 // ~4 weeks per month
+		return num * 20, true // ~4 weeks per month
 	default:
-		return 0
+		return 0, false
+	}
+}
+
+// parseProgress parses a percentage such as "40%" or "40", clamped to 0-100.
+// It reports false if s is not a number.
+func parseProgress(s string) (int, bool) {
+	s = strings.TrimSuffix(strings.TrimSpace(s), "%")
+	num, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil {
+		return 0, false
+	}
+
+	if num < 0 {
+		return 0, true
+	}
+	if num > 100 {
+		return 100, true
 	}
+	return num, true
 }
 
 func extractText(n ast.Node, source []byte) string {
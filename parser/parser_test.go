@@ -4,6 +4,9 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
+
+	"gantt-gen/model"
 )
 
 func TestParse_Headers(t *testing.T) {
@@ -144,6 +147,51 @@ func TestParse_DependencyTable(t *testing.T) {
 	}
 }
 
+func TestParse_DependencyLag(t *testing.T) {
+	input := `# Project
+
+## Design
+
+## Build
+
+| Depends On | Type | Lag |
+|------------|------|-----|
+| Design | finish-to-start | 3d |
+
+## Review
+
+| Depends On | Type | Lag |
+|------------|------|-----|
+| Build | start-to-start | -2d |
+| Design | finish-to-finish | 1w |
+| Build | finish-to-start | |
+`
+
+	project, err := Parse([]byte(input))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if len(project.Tasks) != 3 {
+		t.Fatalf("len(tasks) = %d, want 3", len(project.Tasks))
+	}
+
+	if got := project.Tasks[1].Dependencies[0].Lag; got != 3 {
+		t.Errorf("Build lag = %d, want 3", got)
+	}
+
+	wantLags := []int{-2, 5, 0}
+	deps := project.Tasks[2].Dependencies
+	if len(deps) != len(wantLags) {
+		t.Fatalf("len(dependencies) = %d, want %d", len(deps), len(wantLags))
+	}
+	for i, want := range wantLags {
+		if deps[i].Lag != want {
+			t.Errorf("dependency[%d].Lag = %d, want %d", i, deps[i].Lag, want)
+		}
+	}
+}
+
 func TestParse_CalendarTable(t *testing.T) {
 	input := `# Project
 
@@ -223,6 +271,78 @@ func TestParse_DurationUnits(t *testing.T) {
 	}
 }
 
+func TestParse_Progress(t *testing.T) {
+	tests := []struct {
+		progress string
+		want     int
+	}{
+		{"40%", 40},
+		{"75", 75},
+		{"100 %", 100},
+		{"150%", 100}, // Clamped
+		{"half", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.progress, func(t *testing.T) {
+			input := fmt.Sprintf(`# Project
+
+## Task
+
+| Property | Value |
+|----------|-------|
+| Progress | %s |
+`, tt.progress)
+
+			project, err := Parse([]byte(input))
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			if len(project.Tasks) != 1 {
+				t.Fatalf("len(tasks) = %d, want 1", len(project.Tasks))
+			}
+
+			if project.Tasks[0].Progress != tt.want {
+				t.Errorf("Progress = %d, want %d", project.Tasks[0].Progress, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse_Resources(t *testing.T) {
+	input := `# Project
+
+## Backend
+
+| Property | Value |
+|----------|-------|
+| Assignee | Alice |
+
+## Frontend
+
+| Property | Value |
+|----------|-------|
+| Resources | Bob, Carol , |
+`
+
+	project, err := Parse([]byte(input))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if len(project.Tasks) != 2 {
+		t.Fatalf("len(tasks) = %d, want 2", len(project.Tasks))
+	}
+
+	if got := strings.Join(project.Tasks[0].Resources, "|"); got != "Alice" {
+		t.Errorf("Backend resources = %q, want %q", got, "Alice")
+	}
+	if got := strings.Join(project.Tasks[1].Resources, "|"); got != "Bob|Carol" {
+		t.Errorf("Frontend resources = %q, want %q", got, "Bob|Carol")
+	}
+}
+
 func TestParse_ManyTasks_NoPointerBug(t *testing.T) {
 	// Create enough tasks to force slice reallocation (>32 triggers new backing array)
 	var input strings.Builder
@@ -251,3 +371,123 @@ func TestParse_ManyTasks_NoPointerBug(t *testing.T) {
 		}
 	}
 }
+
+func TestParse_SchedulingConstraints(t *testing.T) {
+	input := `# Project
+
+## Delivery
+
+| Property | Value |
+|----------|-------|
+| Duration | 5d |
+| Not Before | 2024-03-04 |
+| Deadline | 2024-03-15 |
+| Must Finish On | 2024-03-08 |
+`
+
+	project, err := Parse([]byte(input))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	task := project.Tasks[0]
+	if task.NotBefore == nil || !task.NotBefore.Equal(time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("NotBefore = %v, want 2024-03-04", task.NotBefore)
+	}
+	if task.Deadline == nil || !task.Deadline.Equal(time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Deadline = %v, want 2024-03-15", task.Deadline)
+	}
+	if task.MustFinishOn == nil || !task.MustFinishOn.Equal(time.Date(2024, 3, 8, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("MustFinishOn = %v, want 2024-03-08", task.MustFinishOn)
+	}
+}
+
+func TestParse_Positions(t *testing.T) {
+	input := `# Project
+
+## Design
+
+| Property | Value |
+|----------|-------|
+| Duration | 5d |
+
+**Launch**
+
+## Calendar: Team
+
+## Build
+
+| Depends On | Type |
+|------------|------|
+| Design | finish-to-start |
+`
+
+	project, err := Parse([]byte(input))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		got  model.Position
+		want model.Position
+	}{
+		{"Design heading", project.Tasks[0].Pos, model.Position{Line: 3, Column: 4}},
+		{"Duration row", project.Tasks[0].PropertyPos["Duration"], model.Position{Line: 7, Column: 3}},
+		{"Launch milestone", project.Tasks[1].Pos, model.Position{Line: 9, Column: 1}},
+		{"Team calendar", project.Calendars[0].Pos, model.Position{Line: 11, Column: 4}},
+		{"Build heading", project.Tasks[2].Pos, model.Position{Line: 13, Column: 4}},
+		{"Design dependency", project.Tasks[2].Dependencies[0].Pos, model.Position{Line: 17, Column: 3}},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s position = %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestParse_WarnsOnIgnoredInput(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		code  string
+		line  int
+	}{
+		{"unparseable date", "## Task\n\n| Property | Value |\n|---|---|\n| Start | someday |\n", model.CodeInvalidDate, 5},
+		{"duration with words", "## Task\n\n| Property | Value |\n|---|---|\n| Duration | 5 days |\n", model.CodeInvalidDuration, 5},
+		{"fractional duration", "## Task\n\n| Property | Value |\n|---|---|\n| Duration | 1.5w |\n", model.CodeInvalidDuration, 5},
+		{"negative duration", "## Task\n\n| Property | Value |\n|---|---|\n| Duration | -3d |\n", model.CodeInvalidDuration, 5},
+		{"bad lag", "## A\n\n## B\n\n| Depends On | Type | Lag |\n|---|---|---|\n| A | finish-to-start | soon |\n", model.CodeInvalidDuration, 7},
+		{"bad progress", "## Task\n\n| Property | Value |\n|---|---|\n| Progress | half |\n", model.CodeInvalidValue, 5},
+		{"unknown property", "## Task\n\n| Property | Value |\n|---|---|\n| Owner | Sam |\n", model.CodeUnknownProperty, 5},
+		{"non-boolean default", "## Calendar: Team\n\n| Type | Value |\n|---|---|\n| Default | yes |\n", model.CodeInvalidValue, 5},
+		{"unknown weekend day", "## Calendar: Team\n\n| Type | Value |\n|---|---|\n| Weekends | Sat, Caturday |\n", model.CodeUnknownWeekday, 5},
+		{"table before any heading", "| Property | Value |\n|---|---|\n| Duration | 5d |\n", model.CodeOrphanTable, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			project, err := Parse([]byte(tt.input))
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			if len(project.Warnings) != 1 {
+				t.Fatalf("warnings = %v, want 1", project.Warnings)
+			}
+			warning := project.Warnings[0]
+			if warning.Code != tt.code || warning.Pos.Line != tt.line {
+				t.Errorf("warning = %+v, want %s on line %d", warning, tt.code, tt.line)
+			}
+		})
+	}
+
+	// Well-formed input produces no warnings
+	project, err := Parse([]byte("## Task\n\n| Property | Value |\n|---|---|\n| Start | 2024-01-01 |\n| Duration | 2w |\n| Link | |\n"))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(project.Warnings) != 0 {
+		t.Errorf("warnings = %v, want none", project.Warnings)
+	}
+}
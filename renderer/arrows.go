@@ -0,0 +1,160 @@
+package renderer
+
+import (
+	"fmt"
+
+	"gantt-gen/model"
+)
+
+const (
+	arrowColor   = "#666666"
+	arrowStub    = 8.0  // Horizontal run out of/into a bar before an arrow turns
+	milestoneTip = 7.07 // Half diagonal of the milestone diamond
+)
+
+// arrowDefs holds the arrowhead markers referenced by dependency arrows
+const arrowDefs = `<defs>
+        <marker id="arrowhead" viewBox="0 0 10 10" refX="9" refY="5" markerWidth="6" markerHeight="6" orient="auto">
+            <path d="M 0 0 L 10 5 L 0 10 z" fill="` + arrowColor + `"/>
+        </marker>
+        <marker id="arrowhead-critical" viewBox="0 0 10 10" refX="9" refY="5" markerWidth="6" markerHeight="6" orient="auto">
+            <path d="M 0 0 L 10 5 L 0 10 z" fill="` + criticalColor + `"/>
+        </marker>
+    </defs>`
+
+// barAnchor is the horizontal extent and vertical center of a task's bar
+type barAnchor struct {
+	StartX  float64
+	EndX    float64
+	CenterY float64
+}
+
+// dependencyArrow is an elbow connector from a predecessor to a successor
+type dependencyArrow struct {
+	Path   string
+	Color  string
+	Marker string
+}
+
+// milestoneAnchor returns the anchor for a milestone diamond centered at x
+func milestoneAnchor(centerX, centerY float64) barAnchor {
+	return barAnchor{
+		StartX:  centerX - milestoneTip,
+		EndX:    centerX + milestoneTip,
+		CenterY: centerY,
+	}
+}
+
+// anchorKey identifies one drawn copy of a task. With swimlanes a task with
+// several resources is drawn once per lane.
+type anchorKey struct {
+	Lane string
+	Task string
+}
+
+// buildDependencyArrows routes an arrow for every dependency whose tasks are
+// both drawn. Copies in the same lane are connected to each other; tasks that
+// share no lane are connected once, between their first copies.
+func buildDependencyArrows(rows []chartRow, anchors map[anchorKey]barAnchor, rowHeight int) []dependencyArrow {
+	var arrows []dependencyArrow
+
+	critical := make(map[string]bool)
+	lanes := make(map[string][]string) // Lanes each task is drawn in, first lane first
+	for _, row := range rows {
+		if row.Task == nil {
+			continue
+		}
+		if _, ok := anchors[anchorKey{row.Lane, row.Task.Name}]; ok {
+			lanes[row.Task.Name] = append(lanes[row.Task.Name], row.Lane)
+		}
+		critical[row.Task.Name] = row.Task.IsCritical
+	}
+
+	for _, row := range rows {
+		if row.Task == nil {
+			continue
+		}
+		task := row.Task
+		to, ok := anchors[anchorKey{row.Lane, task.Name}]
+		if !ok {
+			continue
+		}
+
+		for _, dep := range task.Dependencies {
+			from, ok := anchors[anchorKey{row.Lane, dep.TaskName}]
+			if !ok {
+				// Only in other lanes: link first copies, unless the tasks also meet in a lane
+				depLanes := lanes[dep.TaskName]
+				if len(depLanes) == 0 || row.Lane != lanes[task.Name][0] || shareLane(lanes[task.Name], depLanes) {
+					continue
+				}
+				from = anchors[anchorKey{depLanes[0], dep.TaskName}]
+			}
+
+			// Anchor points follow the dependency type (unknown types are finish-to-start)
+			fromEnd := dep.Type != model.StartToStart && dep.Type != model.StartToFinish
+			toStart := dep.Type != model.FinishToFinish && dep.Type != model.StartToFinish
+
+			x1 := from.StartX
+			if fromEnd {
+				x1 = from.EndX
+			}
+			x2 := to.EndX
+			if toStart {
+				x2 = to.StartX
+			}
+
+			arrow := dependencyArrow{
+				Path:   elbowPath(x1, from.CenterY, x2, to.CenterY, fromEnd, toStart, float64(rowHeight)),
+				Color:  arrowColor,
+				Marker: "arrowhead",
+			}
+			if task.IsCritical && critical[dep.TaskName] {
+				arrow.Color = criticalColor
+				arrow.Marker = "arrowhead-critical"
+			}
+
+			arrows = append(arrows, arrow)
+		}
+	}
+
+	return arrows
+}
+
+// shareLane reports whether two lists of lanes have one in common
+func shareLane(a, b []string) bool {
+	for _, x := range a {
+		for _, y := range b {
+			if x == y {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// elbowPath builds an orthogonal SVG path that leaves the predecessor on the
+// side of its anchor and enters the successor from the side of its anchor
+func elbowPath(x1, y1, x2, y2 float64, fromEnd, toStart bool, rowHeight float64) string {
+	exitX := x1 - arrowStub
+	if fromEnd {
+		exitX = x1 + arrowStub
+	}
+	entryX := x2 + arrowStub
+	if toStart {
+		entryX = x2 - arrowStub
+	}
+
+	// One bend is enough when the target is on the correct side of the exit
+	if (toStart && exitX <= entryX) || (!toStart && exitX >= entryX) {
+		return fmt.Sprintf("M %.2f %.2f H %.2f V %.2f H %.2f", x1, y1, exitX, y2, x2)
+	}
+
+	// Otherwise detour along the row boundary next to the successor
+	midY := y2 + rowHeight/2
+	if y2 > y1 {
+		midY = y2 - rowHeight/2
+	}
+
+	return fmt.Sprintf("M %.2f %.2f H %.2f V %.2f H %.2f V %.2f H %.2f", x1, y1, exitX, midY, entryX, y2, x2)
+}
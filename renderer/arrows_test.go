@@ -0,0 +1,170 @@
+package renderer
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"gantt-gen/model"
+)
+
+func TestElbowPath(t *testing.T) {
+	tests := []struct {
+		name    string
+		x1, x2  float64
+		fromEnd bool
+		toStart bool
+		want    string
+	}{
+		{
+			name:    "finish-to-start with room for a single bend",
+			x1:      100,
+			x2:      150,
+			fromEnd: true,
+			toStart: true,
+			want:    "M 100.00 20.00 H 108.00 V 60.00 H 150.00",
+		},
+		{
+			name:    "finish-to-start touching bars detours around the successor",
+			x1:      100,
+			x2:      100,
+			fromEnd: true,
+			toStart: true,
+			want:    "M 100.00 20.00 H 108.00 V 40.00 H 92.00 V 60.00 H 100.00",
+		},
+		{
+			name:    "finish-to-finish enters from the right",
+			x1:      100,
+			x2:      100,
+			fromEnd: true,
+			toStart: false,
+			want:    "M 100.00 20.00 H 108.00 V 60.00 H 100.00",
+		},
+		{
+			name:    "start-to-start leaves to the left",
+			x1:      100,
+			x2:      100,
+			fromEnd: false,
+			toStart: true,
+			want:    "M 100.00 20.00 H 92.00 V 60.00 H 100.00",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := elbowPath(tt.x1, 20, tt.x2, 60, tt.fromEnd, tt.toStart, 40)
+			if got != tt.want {
+				t.Errorf("elbowPath() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildDependencyArrows_Anchors(t *testing.T) {
+	anchors := map[anchorKey]barAnchor{
+		{Task: "Task A"}: {StartX: 10, EndX: 50, CenterY: 20},
+		{Task: "Task B"}: {StartX: 100, EndX: 140, CenterY: 60},
+	}
+
+	tests := []struct {
+		depType model.DependencyType
+		prefix  string // Start of the path, fixed by the predecessor anchor
+		suffix  string // End of the path, fixed by the successor anchor
+	}{
+		{model.FinishToStart, "M 50.00 20.00", "H 100.00"},
+		{model.StartToStart, "M 10.00 20.00", "H 100.00"},
+		{model.FinishToFinish, "M 50.00 20.00", "H 140.00"},
+		{model.StartToFinish, "M 10.00 20.00", "H 140.00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.depType), func(t *testing.T) {
+			rows := []chartRow{
+				{Task: &model.Task{Name: "Task A"}},
+				{Task: &model.Task{Name: "Task B", Dependencies: []model.Dependency{{TaskName: "Task A", Type: tt.depType}}}},
+			}
+
+			arrows := buildDependencyArrows(rows, anchors, 40)
+			if len(arrows) != 1 {
+				t.Fatalf("len(arrows) = %d, want 1", len(arrows))
+			}
+			if !strings.HasPrefix(arrows[0].Path, tt.prefix) || !strings.HasSuffix(arrows[0].Path, tt.suffix) {
+				t.Errorf("path = %q, want prefix %q and suffix %q", arrows[0].Path, tt.prefix, tt.suffix)
+			}
+		})
+	}
+}
+
+func TestBuildDependencyArrows_Swimlanes(t *testing.T) {
+	design := &model.Task{Name: "Design", Resources: []string{"Alice", "Bob"}}
+	build := &model.Task{Name: "Build", Resources: []string{"Alice", "Bob"},
+		Dependencies: []model.Dependency{{TaskName: "Design", Type: model.FinishToStart}}}
+	review := &model.Task{Name: "Review", Resources: []string{"Carol"},
+		Dependencies: []model.Dependency{{TaskName: "Build", Type: model.FinishToStart}}}
+
+	rows := []chartRow{
+		{Lane: "Alice"}, {Task: design, Lane: "Alice"}, {Task: build, Lane: "Alice"},
+		{Lane: "Bob"}, {Task: design, Lane: "Bob"}, {Task: build, Lane: "Bob"},
+		{Lane: "Carol"}, {Task: review, Lane: "Carol"},
+	}
+	anchors := map[anchorKey]barAnchor{
+		{"Alice", "Design"}: {StartX: 10, EndX: 50, CenterY: 60},
+		{"Alice", "Build"}:  {StartX: 100, EndX: 140, CenterY: 100},
+		{"Bob", "Design"}:   {StartX: 10, EndX: 50, CenterY: 180},
+		{"Bob", "Build"}:    {StartX: 100, EndX: 140, CenterY: 220},
+		{"Carol", "Review"}: {StartX: 200, EndX: 240, CenterY: 300},
+	}
+
+	// Each lane links its own copies; Review links once, from Build's first copy
+	want := []string{
+		"M 50.00 60.00 H 58.00 V 100.00 H 100.00",
+		"M 50.00 180.00 H 58.00 V 220.00 H 100.00",
+		"M 140.00 100.00 H 148.00 V 300.00 H 200.00",
+	}
+
+	arrows := buildDependencyArrows(rows, anchors, 40)
+	if len(arrows) != len(want) {
+		t.Fatalf("len(arrows) = %d, want %d: %+v", len(arrows), len(want), arrows)
+	}
+	for i, path := range want {
+		if arrows[i].Path != path {
+			t.Errorf("arrows[%d] = %q, want %q", i, arrows[i].Path, path)
+		}
+	}
+}
+
+func TestRenderSVG_DependencyArrows(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	mid := time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	project := &model.Project{
+		Name: "Test Project",
+		Tasks: []model.Task{
+			{Name: "Task A", Level: 2, CalculatedStart: &start, CalculatedEnd: &mid},
+			{
+				Name:            "Task B",
+				Level:           2,
+				CalculatedStart: &mid,
+				CalculatedEnd:   &end,
+				Dependencies:    []model.Dependency{{TaskName: "Task A", Type: model.FinishToStart}},
+			},
+		},
+	}
+
+	svg, err := RenderSVG(project)
+	if err != nil {
+		t.Fatalf("RenderSVG() error = %v", err)
+	}
+	if !strings.Contains(svg, `marker-end="url(#arrowhead)"`) {
+		t.Error("SVG should draw an arrow for the dependency")
+	}
+
+	html, err := RenderHTML(project)
+	if err != nil {
+		t.Fatalf("RenderHTML() error = %v", err)
+	}
+	if !strings.Contains(html, `marker-end="url(#arrowhead)"`) {
+		t.Error("HTML should draw an arrow for the dependency")
+	}
+}
@@ -0,0 +1,40 @@
+package renderer
+
+import (
+	"fmt"
+	"time"
+
+	"gantt-gen/model"
+)
+
+// baselineBar returns the x position and width of a task's ghost bar, drawn
+// at the task's baseline dates; ok is false when there is nothing to draw
+func baselineBar(task model.Task, opts Options, dateX func(time.Time) float64) (x, width float64, ok bool) {
+	if opts.Baseline == nil {
+		return 0, 0, false
+	}
+	dates, ok := opts.Baseline.Tasks[task.Name]
+	if !ok {
+		return 0, 0, false
+	}
+
+	x = dateX(dates.Start)
+	width = dateX(dates.End) - x
+	if width < 5 {
+		width = 5 // Minimum width for visibility, as for regular bars
+	}
+	return x, width, true
+}
+
+// varianceLabel is appended to a bar's date label when its finish has moved
+// since the baseline, e.g. " [+3d]"
+func varianceLabel(project *model.Project, task *model.Task, opts Options) string {
+	if opts.Baseline == nil {
+		return ""
+	}
+	v, ok := opts.Baseline.TaskVariance(project, task)
+	if !ok || v.Finish == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" [%+dd]", v.Finish)
+}
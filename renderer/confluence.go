@@ -2,9 +2,7 @@ package renderer
 
 import (
 	"bytes"
-	"fmt"
 	"text/template"
-	"time"
 
 	"gantt-gen/model"
 )
@@ -36,25 +34,27 @@ const confluenceTemplate = `<div style="display: flex; max-width: 100%; overflow
 </div>
 `
 
+func init() {
+	Register(Format{
+		Name:        "confluence",
+		Description: "HTML snippet for the Confluence HTML macro",
+		Extensions:  []string{".confluence.html"},
+		MIMEType:    "text/html",
+		Renderer:    RendererFunc(RenderConfluenceWithOptions),
+	})
+}
+
 // RenderConfluence generates a minimal HTML snippet for Confluence
 func RenderConfluence(project *model.Project) (string, error) {
-	// Find date range
-	var minDate, maxDate time.Time
-	for _, task := range project.Tasks {
-		if task.CalculatedStart != nil {
-			if minDate.IsZero() || task.CalculatedStart.Before(minDate) {
-				minDate = *task.CalculatedStart
-			}
-		}
-		if task.CalculatedEnd != nil {
-			if maxDate.IsZero() || task.CalculatedEnd.After(maxDate) {
-				maxDate = *task.CalculatedEnd
-			}
-		}
-	}
+	return RenderConfluenceWithOptions(project, Options{})
+}
 
-	if minDate.IsZero() || maxDate.IsZero() {
-		return "", fmt.Errorf("no tasks with calculated dates")
+// RenderConfluenceWithOptions generates the Confluence snippet, drawing the optional features enabled in opts
+func RenderConfluenceWithOptions(project *model.Project, opts Options) (string, error) {
+	// Find date range
+	minDate, maxDate, err := chartRange(project, opts)
+	if err != nil {
+		return "", err
 	}
 
 	totalDays := maxDate.Sub(minDate).Hours() / 24
@@ -71,18 +71,23 @@ func RenderConfluence(project *model.Project) (string, error) {
 	milestonePadding := milestoneRadius * 2
 	effectiveTimelineWidth := float64(timelineWidth) - milestonePadding
 
+	rows, err := layoutRows(project, opts)
+	if err != nil {
+		return "", err
+	}
+
 	rowHeight := 40
 	headerHeight := 40
-	totalHeight := headerHeight + (len(project.Tasks) * rowHeight)
+	totalHeight := headerHeight + (len(rows) * rowHeight)
 
 	// Generate task column (reuse from html.go)
-	taskColumnSVG, err := renderTaskColumn(project, totalHeight)
+	taskColumnSVG, err := renderTaskColumn(rows, totalHeight)
 	if err != nil {
 		return "", err
 	}
 
 	// Generate timeline (reuse from html.go)
-	timelineSVG, err := renderTimeline(project, minDate, maxDate, timelineWidth, effectiveTimelineWidth, totalHeight, milestonePadding)
+	timelineSVG, err := renderTimeline(project, rows, opts, minDate, maxDate, timelineWidth, effectiveTimelineWidth, totalHeight, milestonePadding)
 	if err != nil {
 		return "", err
 	}
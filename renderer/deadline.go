@@ -0,0 +1,30 @@
+package renderer
+
+import (
+	"fmt"
+	"time"
+
+	"gantt-gen/model"
+)
+
+const (
+	deadlineColor       = "#2c3e50" // Deadline flag on a task row
+	missedDeadlineColor = "#c0392b" // Flag and bar of a task finishing after its deadline
+)
+
+// deadlineFlagPath draws a flag on a row at y: a pole spanning the row with a
+// pennant at the top pointing right
+func deadlineFlagPath(x float64, y int) string {
+	top := float64(y + 4)
+	return fmt.Sprintf("M %.2f %.2f V %.2f M %.2f %.2f L %.2f %.2f L %.2f %.2f Z",
+		x, top, top+32, x, top, x+8, top+4, x, top+8)
+}
+
+// deadlineFlag returns the flag path for a task's deadline and whether it is
+// drawn at all; deadlines outside the chart's date range are skipped
+func deadlineFlag(task model.Task, minDate, maxDate time.Time, y int, dateX func(time.Time) float64) (string, bool) {
+	if task.Deadline == nil || task.Deadline.Before(minDate) || task.Deadline.After(maxDate) {
+		return "", false
+	}
+	return deadlineFlagPath(dateX(*task.Deadline), y), true
+}
@@ -0,0 +1,315 @@
+package renderer
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+	"time"
+
+	"gantt-gen/history"
+)
+
+const historyHTMLTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>{{.File}} - Schedule History</title>
+    <style>
+        body {
+            margin: 0;
+            font-family: Arial, sans-serif;
+            background: #f5f5f5;
+        }
+
+        .chart {
+            margin: 20px;
+            overflow-x: auto;
+            background: white;
+            border: 1px solid #e0e0e0;
+        }
+
+        svg {
+            display: block;
+        }
+
+        table {
+            margin: 20px;
+            border-collapse: collapse;
+            background: white;
+            font-size: 13px;
+        }
+
+        th, td {
+            padding: 6px 12px;
+            border: 1px solid #e0e0e0;
+            text-align: left;
+        }
+
+        .error {
+            color: #c0392b;
+        }
+    </style>
+</head>
+<body>
+    <div class="chart">
+<svg xmlns="http://www.w3.org/2000/svg" width="{{.Width}}" height="{{.Height}}" viewBox="0 0 {{.Width}} {{.Height}}">
+    <rect width="{{.Width}}" height="{{.Height}}" fill="#ffffff"/>
+
+    <!-- Title -->
+    <text x="20" y="30" font-family="Arial, sans-serif" font-size="20" font-weight="bold" fill="#333">
+        {{.File}} - Schedule History
+    </text>
+
+    <!-- Date axis -->
+    {{range $tick := .Ticks}}
+    <line x1="{{$.PlotLeft}}" y1="{{$tick.Y}}" x2="{{$.PlotRight}}" y2="{{$tick.Y}}" stroke="#eee"/>
+    <text x="{{$.TickLabelX}}" y="{{$tick.Y}}" font-family="Arial, sans-serif" font-size="11" fill="#666" text-anchor="end" dy="4">
+        {{$tick.Label}}
+    </text>
+    {{end}}
+
+    <!-- Revision axis -->
+    {{range $rev := .Revisions}}
+    <line x1="{{$rev.X}}" y1="{{$.PlotTop}}" x2="{{$rev.X}}" y2="{{$.PlotBottom}}" stroke="#f3f3f3"/>
+    <text x="{{$rev.X}}" y="{{$.RevisionLabelY}}" font-family="Arial, sans-serif" font-size="11" fill="{{if $rev.Failed}}#c0392b{{else}}#333{{end}}" text-anchor="middle">
+        {{$rev.Label}}
+    </text>
+    {{end}}
+
+    <!-- Series -->
+    {{range $series := .Series}}
+    <polyline points="{{$series.Points}}" fill="none" stroke="{{$series.Color}}" stroke-width="{{$series.Width}}"/>
+    {{range $dot := $series.Dots}}
+    <circle cx="{{$dot.X}}" cy="{{$dot.Y}}" r="3" fill="{{$series.Color}}"/>
+    {{end}}
+    {{end}}
+
+    <!-- Legend -->
+    {{range $series := .Series}}
+    <rect x="{{$series.LegendX}}" y="{{$.LegendY}}" width="12" height="12" fill="{{$series.Color}}"/>
+    <text x="{{$series.LegendTextX}}" y="{{$.LegendY}}" font-family="Arial, sans-serif" font-size="12" fill="#333" dy="10">
+        {{$series.Name}}
+    </text>
+    {{end}}
+</svg>
+    </div>
+
+    <table>
+        <tr><th>Date</th><th>Commit</th><th>Projected finish</th><th>Change</th><th>Subject</th></tr>
+        {{range $row := .Rows}}
+        <tr>
+            <td>{{$row.Date}}</td>
+            <td><code>{{$row.Commit}}</code></td>
+            {{if $row.Error}}
+            <td class="error" colspan="2">{{$row.Error | html}}</td>
+            {{else}}
+            <td>{{$row.Finish}}</td>
+            <td>{{$row.Slip}}</td>
+            {{end}}
+            <td>{{$row.Subject | html}}</td>
+        </tr>
+        {{end}}
+    </table>
+</body>
+</html>
+`
+
+const (
+	historyRevisionPx = 90  // Horizontal space per revision
+	historyPlotHeight = 300 // Height of the plot area
+	historyPlotLeft   = 110 // Room for date labels
+	historyPlotTop    = 60
+)
+
+// historySeriesColors cycles across top-level tasks; the project finish is always drawn in dark blue
+var historySeriesColors = []string{"#4a90e2", "#27ae60", "#e67e22", "#8e44ad", "#16a085", "#d35400", "#7f8c8d"}
+
+type historyPoint struct {
+	X float64
+	Y float64
+}
+
+type historySeries struct {
+	Name        string
+	Color       string
+	Width       float64
+	Points      string
+	Dots        []historyPoint
+	LegendX     float64
+	LegendTextX float64
+}
+
+type historyRevision struct {
+	X      float64
+	Label  string
+	Failed bool
+}
+
+type historyTick struct {
+	Y     float64
+	Label string
+}
+
+type historyRow struct {
+	Date    string
+	Commit  string
+	Finish  string
+	Slip    string
+	Error   string
+	Subject string
+}
+
+type historyData struct {
+	File           string
+	Width          int
+	Height         int
+	PlotLeft       int
+	PlotRight      int
+	PlotTop        int
+	PlotBottom     int
+	TickLabelX     int
+	RevisionLabelY int
+	LegendY        int
+	Ticks          []historyTick
+	Revisions      []historyRevision
+	Series         []historySeries
+	Rows           []historyRow
+}
+
+// RenderHistoryHTML generates a slip chart: the projected finish and each
+// top-level task's end date plotted across the plan's revisions
+func RenderHistoryHTML(report *history.Report) (string, error) {
+	var minDate, maxDate time.Time
+	for _, p := range report.Points {
+		if p.Err != nil {
+			continue
+		}
+		dates := []time.Time{p.Finish}
+		for _, end := range p.Ends {
+			dates = append(dates, end)
+		}
+		for _, d := range dates {
+			if minDate.IsZero() || d.Before(minDate) {
+				minDate = d
+			}
+			if maxDate.IsZero() || d.After(maxDate) {
+				maxDate = d
+			}
+		}
+	}
+	if minDate.IsZero() {
+		return "", fmt.Errorf("no revisions of %s could be resolved", report.File)
+	}
+	if !maxDate.After(minDate) {
+		maxDate = minDate.AddDate(0, 0, 1) // Keep a flat history drawable
+	}
+
+	plotWidth := historyRevisionPx * len(report.Points)
+	plotRight := historyPlotLeft + plotWidth
+	plotBottom := historyPlotTop + historyPlotHeight
+	totalDays := maxDate.Sub(minDate).Hours() / 24
+
+	revisionX := func(i int) float64 {
+		return float64(historyPlotLeft) + (float64(i)+0.5)*historyRevisionPx
+	}
+	// Later dates are drawn higher up, so slips climb
+	dateY := func(d time.Time) float64 {
+		offset := d.Sub(minDate).Hours() / 24
+		return float64(plotBottom) - offset/totalDays*historyPlotHeight
+	}
+
+	data := historyData{
+		File:           report.File,
+		Width:          plotRight + 40,
+		Height:         plotBottom + 80,
+		PlotLeft:       historyPlotLeft,
+		PlotRight:      plotRight,
+		PlotTop:        historyPlotTop,
+		PlotBottom:     plotBottom,
+		TickLabelX:     historyPlotLeft - 10,
+		RevisionLabelY: plotBottom + 20,
+		LegendY:        plotBottom + 45,
+	}
+
+	const tickCount = 5
+	for i := 0; i <= tickCount; i++ {
+		d := minDate.Add(time.Duration(float64(maxDate.Sub(minDate)) * float64(i) / tickCount))
+		data.Ticks = append(data.Ticks, historyTick{Y: dateY(d), Label: d.Format("Jan 2 2006")})
+	}
+
+	for i, p := range report.Points {
+		data.Revisions = append(data.Revisions, historyRevision{
+			X:      revisionX(i),
+			Label:  p.Date.Format("Jan 2"),
+			Failed: p.Err != nil,
+		})
+
+		row := historyRow{
+			Date:    p.Date.Format("2006-01-02"),
+			Commit:  p.ShortCommit(),
+			Subject: p.Subject,
+		}
+		if p.Err != nil {
+			row.Error = p.Err.Error()
+		} else {
+			row.Finish = p.Finish.Format("2006-01-02")
+			if days, ok := report.Slip(i); ok && days != 0 {
+				row.Slip = fmt.Sprintf("%+d business days", days)
+			}
+		}
+		data.Rows = append(data.Rows, row)
+	}
+
+	// Project finish first, then one series per top-level task
+	addSeries := func(name, color string, width float64, date func(history.Point) (time.Time, bool)) {
+		series := historySeries{Name: name, Color: color, Width: width}
+		for i, p := range report.Points {
+			if p.Err != nil {
+				continue
+			}
+			d, ok := date(p)
+			if !ok {
+				continue
+			}
+			pt := historyPoint{X: revisionX(i), Y: dateY(d)}
+			series.Dots = append(series.Dots, pt)
+			series.Points += fmt.Sprintf("%.2f,%.2f ", pt.X, pt.Y)
+		}
+		data.Series = append(data.Series, series)
+	}
+
+	addSeries("Project finish", "#2c3e50", 3, func(p history.Point) (time.Time, bool) {
+		return p.Finish, true
+	})
+	for i, name := range report.Tasks {
+		name := name
+		addSeries(name, historySeriesColors[i%len(historySeriesColors)], 1.5, func(p history.Point) (time.Time, bool) {
+			end, ok := p.Ends[name]
+			return end, ok
+		})
+	}
+
+	// Lay the legend out in a row, estimating label widths like task names
+	legendX := float64(historyPlotLeft)
+	for i := range data.Series {
+		data.Series[i].LegendX = legendX
+		data.Series[i].LegendTextX = legendX + 18
+		legendX += 18 + float64(len([]rune(data.Series[i].Name)))*avgCharWidthPixels + 20
+	}
+	if int(legendX) > data.Width {
+		data.Width = int(legendX)
+	}
+
+	tmpl, err := template.New("history").Parse(historyHTMLTemplate)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
@@ -0,0 +1,43 @@
+package renderer
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"gantt-gen/history"
+)
+
+func TestRenderHistoryHTML(t *testing.T) {
+	jan := func(day int) time.Time { return time.Date(2024, 1, day, 0, 0, 0, 0, time.UTC) }
+
+	report := &history.Report{
+		File:  "plan.md",
+		Tasks: []string{"Design"},
+		Points: []history.Point{
+			{Revision: history.Revision{Commit: "aaaaaaaaaa", Subject: "Initial"}, Finish: jan(15), Ends: map[string]time.Time{"Design": jan(5)}},
+			{Revision: history.Revision{Commit: "bbbbbbbbbb", Subject: "Broken <plan>"}, Err: errors.New("bad")},
+			{Revision: history.Revision{Commit: "cccccccccc", Subject: "Slip"}, Finish: jan(18), Ends: map[string]time.Time{"Design": jan(10)}},
+		},
+	}
+
+	html, err := RenderHistoryHTML(report)
+	if err != nil {
+		t.Fatalf("RenderHistoryHTML() error = %v", err)
+	}
+
+	if got := strings.Count(html, "<polyline"); got != 2 {
+		t.Errorf("series = %d, want project finish and Design", got)
+	}
+	if !strings.Contains(html, "Broken &lt;plan&gt;") {
+		t.Error("commit subjects should be escaped")
+	}
+	if !strings.Contains(html, "+3 business days") {
+		t.Error("table should show the finish slip")
+	}
+
+	if _, err := RenderHistoryHTML(&history.Report{File: "plan.md"}); err == nil {
+		t.Error("RenderHistoryHTML() expected error without resolved revisions")
+	}
+}
@@ -141,6 +141,13 @@ const taskColumnSVGTemplate = `<svg xmlns="http://www.w3.org/2000/svg" width="24
 
     <!-- Task rows -->
     {{range $task := .Tasks}}
+    {{if $task.IsLane}}
+    <rect x="0" y="{{$task.Y}}" width="240" height="40" fill="#f0f4f8" stroke="#eee"/>
+    <text x="{{$task.NameX}}" y="{{$task.TextY}}"
+          font-family="Arial, sans-serif" font-size="13" font-weight="bold" fill="#333">
+        {{$task.DisplayName}}
+    </text>
+    {{else}}
     <rect x="0" y="{{$task.Y}}" width="240" height="40" fill="none" stroke="#eee"/>
     <text x="{{$task.NameX}}" y="{{$task.TextY}}"
           font-family="Arial, sans-serif" font-size="13"
@@ -148,9 +155,11 @@ const taskColumnSVGTemplate = `<svg xmlns="http://www.w3.org/2000/svg" width="24
         {{$task.DisplayName}}
     </text>
     {{end}}
+    {{end}}
 </svg>`
 
 const timelineSVGTemplate = `<svg xmlns="http://www.w3.org/2000/svg" width="{{.Width}}" height="{{.Height}}" viewBox="0 0 {{.Width}} {{.Height}}">
+    ` + arrowDefs + `
     <rect width="{{.Width}}" height="{{.Height}}" fill="#ffffff"/>
 
     <!-- Timeline header cells -->
@@ -163,20 +172,50 @@ const timelineSVGTemplate = `<svg xmlns="http://www.w3.org/2000/svg" width="{{.W
 
     <!-- Task timeline rows -->
     {{range $task := .Tasks}}
+    {{if $task.IsLane}}
+    <rect x="0" y="{{$task.Y}}" width="{{$.Width}}" height="40" fill="#f0f4f8" stroke="#eee"/>
+    {{else}}
     <rect x="0" y="{{$task.Y}}" width="{{$.Width}}" height="40" fill="none" stroke="#eee"/>
 
+    {{if $task.ShowBaseline}}
+    <rect class="baseline" x="{{$task.BaselineX}}" y="{{$task.BaselineY}}" width="{{$task.BaselineWidth}}" height="4" fill="#95a5a6" rx="1"/>
+    {{end}}
+
     <!-- Task bar or milestone -->
     {{if $task.IsMilestone}}
     <rect x="{{$task.BarX}}" y="{{$task.MilestoneY}}" width="10" height="10"
-          fill="#e74c3c" transform="rotate(45 {{$task.MilestoneCenterX}} {{$task.MilestoneCenterY}})"/>
+          fill="{{$task.MilestoneColor}}"{{if $task.IsCritical}} stroke="#7b1f1a" stroke-width="2"{{end}} transform="rotate(45 {{$task.MilestoneCenterX}} {{$task.MilestoneCenterY}})"/>
+    {{else if $task.IsSummary}}
+    <path d="{{$task.SummaryPath}}" fill="{{$task.SummaryColor}}"/>
     {{else}}
     <rect x="{{$task.BarX}}" y="{{$task.BarY}}" width="{{$task.BarWidth}}" height="28"
           fill="{{$task.Color}}" rx="3"/>
+    {{if gt $task.Progress 0}}
+    <rect x="{{$task.BarX}}" y="{{$task.BarY}}" width="{{$task.ProgressWidth}}" height="28"
+          fill="#000000" fill-opacity="0.25" rx="3"/>
+    {{end}}
     <text x="{{$task.DateX}}" y="{{$task.DateY}}"
           font-family="Arial, sans-serif" font-size="10" fill="white">
         {{$task.DateRange}}
     </text>
     {{end}}
+    {{if $task.ShowDeadline}}
+    <path class="deadline" d="{{$task.DeadlinePath}}" fill="{{$task.DeadlineColor}}" stroke="{{$task.DeadlineColor}}" stroke-width="1.5"/>
+    {{end}}
+    {{end}}
+    {{end}}
+
+    <!-- Dependency arrows -->
+    {{range $arrow := .Arrows}}
+    <path d="{{$arrow.Path}}" fill="none" stroke="{{$arrow.Color}}" stroke-width="1.5" marker-end="url(#{{$arrow.Marker}})"/>
+    {{end}}
+
+    <!-- Status date -->
+    {{if .ShowStatusLine}}
+    <line x1="{{.StatusX}}" y1="40" x2="{{.StatusX}}" y2="{{.Height}}" stroke="#e67e22" stroke-width="2" stroke-dasharray="6,4"/>
+    <text x="{{.StatusX}}" y="37" font-family="Arial, sans-serif" font-size="10" fill="#e67e22" text-anchor="middle">
+        {{.StatusLabel}}
+    </text>
     {{end}}
 </svg>`
 
@@ -197,6 +236,7 @@ type taskColumnTask struct {
 	TextY       int
 	DisplayName string
 	IsMilestone bool
+	IsLane      bool
 }
 
 type timelineData struct {
@@ -204,6 +244,11 @@ type timelineData struct {
 	Height        int
 	TimelineCells []timelineHeaderCell
 	Tasks         []timelineTask
+	Arrows        []dependencyArrow
+
+	ShowStatusLine bool
+	StatusX        float64
+	StatusLabel    string
 }
 
 type timelineHeaderCell struct {
@@ -218,6 +263,8 @@ type timelineTask struct {
 	BarX             float64
 	BarY             int
 	BarWidth         float64
+	ProgressWidth    float64
+	Progress         int
 	MilestoneY       int
 	MilestoneCenterX float64
 	MilestoneCenterY int
@@ -226,27 +273,42 @@ type timelineTask struct {
 	DateRange        string
 	Color            string
 	IsMilestone      bool
+	IsCritical       bool
+	MilestoneColor   string
+	IsLane           bool
+	IsSummary        bool
+	SummaryPath      string
+	SummaryColor     string
+	ShowDeadline     bool
+	DeadlinePath     string
+	DeadlineColor    string
+	ShowBaseline     bool
+	BaselineX        float64
+	BaselineY        int
+	BaselineWidth    float64
+}
+
+func init() {
+	Register(Format{
+		Name:        "html",
+		Description: "HTML page with a fixed task column and scrollable timeline",
+		Extensions:  []string{".html", ".htm"},
+		MIMEType:    "text/html",
+		Renderer:    RendererFunc(RenderHTMLWithOptions),
+	})
 }
 
 // RenderHTML generates an HTML file with scrollable Gantt chart
 func RenderHTML(project *model.Project) (string, error) {
-	// Find date range
-	var minDate, maxDate time.Time
-	for _, task := range project.Tasks {
-		if task.CalculatedStart != nil {
-			if minDate.IsZero() || task.CalculatedStart.Before(minDate) {
-				minDate = *task.CalculatedStart
-			}
-		}
-		if task.CalculatedEnd != nil {
-			if maxDate.IsZero() || task.CalculatedEnd.After(maxDate) {
-				maxDate = *task.CalculatedEnd
-			}
-		}
-	}
+	return RenderHTMLWithOptions(project, Options{})
+}
 
-	if minDate.IsZero() || maxDate.IsZero() {
-		return "", fmt.Errorf("no tasks with calculated dates")
+// RenderHTMLWithOptions generates the scrollable HTML Gantt chart, drawing the optional features enabled in opts
+func RenderHTMLWithOptions(project *model.Project, opts Options) (string, error) {
+	// Find date range
+	minDate, maxDate, err := chartRange(project, opts)
+	if err != nil {
+		return "", err
 	}
 
 	totalDays := maxDate.Sub(minDate).Hours() / 24
@@ -263,18 +325,23 @@ func RenderHTML(project *model.Project) (string, error) {
 	milestonePadding := milestoneRadius * 2
 	effectiveTimelineWidth := float64(timelineWidth) - milestonePadding
 
+	rows, err := layoutRows(project, opts)
+	if err != nil {
+		return "", err
+	}
+
 	rowHeight := 40
 	headerHeight := 40
-	totalHeight := headerHeight + (len(project.Tasks) * rowHeight)
+	totalHeight := headerHeight + (len(rows) * rowHeight)
 
 	// Generate task column
-	taskColumnSVG, err := renderTaskColumn(project, totalHeight)
+	taskColumnSVG, err := renderTaskColumn(rows, totalHeight)
 	if err != nil {
 		return "", err
 	}
 
 	// Generate timeline
-	timelineSVG, err := renderTimeline(project, minDate, maxDate, timelineWidth, effectiveTimelineWidth, totalHeight, milestonePadding)
+	timelineSVG, err := renderTimeline(project, rows, opts, minDate, maxDate, timelineWidth, effectiveTimelineWidth, totalHeight, milestonePadding)
 	if err != nil {
 		return "", err
 	}
@@ -299,12 +366,24 @@ func RenderHTML(project *model.Project) (string, error) {
 	return buf.String(), nil
 }
 
-func renderTaskColumn(project *model.Project, height int) (string, error) {
+func renderTaskColumn(rows []chartRow, height int) (string, error) {
 	var tasks []taskColumnTask
 
-	for i, task := range project.Tasks {
+	for i, row := range rows {
 		y := 40 + (i * 40) // header height + row offset
 
+		if row.Task == nil {
+			tasks = append(tasks, taskColumnTask{
+				Y:           y,
+				NameX:       10,
+				TextY:       y + 25,
+				DisplayName: truncateTaskName(row.Lane, 2),
+				IsLane:      true,
+			})
+			continue
+		}
+		task := *row.Task
+
 		displayName := truncateTaskName(task.Name, task.Level)
 		nameX := 10 + (task.Level-2)*20
 
@@ -340,9 +419,15 @@ func renderTaskColumn(project *model.Project, height int) (string, error) {
 	return buf.String(), nil
 }
 
-func renderTimeline(project *model.Project, minDate, maxDate time.Time, timelineWidth int, effectiveTimelineWidth float64, height int, milestonePadding float64) (string, error) {
+func renderTimeline(project *model.Project, rows []chartRow, opts Options, minDate, maxDate time.Time, timelineWidth int, effectiveTimelineWidth float64, height int, milestonePadding float64) (string, error) {
 	totalDays := maxDate.Sub(minDate).Hours() / 24
 
+	// dateX maps a date to its x position on the timeline
+	dateX := func(date time.Time) float64 {
+		offset := date.Sub(minDate).Hours() / 24
+		return (offset/totalDays)*effectiveTimelineWidth + milestonePadding/2
+	}
+
 	// Add right padding to prevent milestone truncation
 	const rightPadding = 20
 	timelineSVGWidth := timelineWidth + rightPadding
@@ -361,24 +446,33 @@ func renderTimeline(project *model.Project, minDate, maxDate time.Time, timeline
 
 	// Generate task timeline rows
 	var tasks []timelineTask
-	for i, task := range project.Tasks {
+	anchors := make(map[anchorKey]barAnchor)
+	for i, row := range rows {
 		y := 40 + (i * 40) // header height + row offset
 
+		if row.Task == nil {
+			tasks = append(tasks, timelineTask{Y: y, IsLane: true})
+			continue
+		}
+		task := *row.Task
+
 		tt := timelineTask{
 			Y:           y,
 			IsMilestone: task.IsMilestone,
+			IsCritical:  task.IsCritical,
 		}
 
-		// Color by level
-		switch task.Level {
-		case 2:
-			tt.Color = "#4a90e2"
-		case 3:
-			tt.Color = "#7eb0e8"
-		case 4:
-			tt.Color = "#a8c9ed"
-		default:
-			tt.Color = "#4a90e2"
+		tt.Color = barColor(task, opts)
+		tt.MilestoneColor = milestoneColor
+		if !opts.StatusDate.IsZero() && task.IsOverdue(opts.StatusDate) {
+			tt.MilestoneColor = overdueColor
+		}
+		tt.DeadlinePath, tt.ShowDeadline = deadlineFlag(task, minDate, maxDate, y, dateX)
+		tt.BaselineX, tt.BaselineWidth, tt.ShowBaseline = baselineBar(task, opts, dateX)
+		tt.BaselineY = y + 35
+		tt.DeadlineColor = deadlineColor
+		if task.MissesDeadline() {
+			tt.DeadlineColor = missedDeadlineColor
 		}
 
 		if task.CalculatedStart != nil && task.CalculatedEnd != nil {
@@ -405,6 +499,27 @@ func renderTimeline(project *model.Project, minDate, maxDate time.Time, timeline
 			tt.DateRange = fmt.Sprintf("%s - %s",
 				task.CalculatedStart.Format("Jan 2"),
 				task.CalculatedEnd.Format("Jan 2"))
+
+			if task.IsSummary {
+				tt.IsSummary = true
+				tt.SummaryPath = summaryPath(tt.BarX, float64(tt.BarY), barWidth)
+				tt.SummaryColor = summaryColor(task)
+			}
+
+			tt.DateRange += varianceLabel(project, row.Task, opts)
+
+			if task.Progress > 0 {
+				tt.Progress = task.Progress
+				tt.ProgressWidth = barWidth * float64(task.Progress) / 100
+				tt.DateRange += fmt.Sprintf(" (%d%%)", task.Progress)
+			}
+
+			key := anchorKey{row.Lane, task.Name}
+			if task.IsMilestone {
+				anchors[key] = milestoneAnchor(tt.MilestoneCenterX, float64(tt.MilestoneCenterY))
+			} else {
+				anchors[key] = barAnchor{StartX: tt.BarX, EndX: tt.BarX + tt.BarWidth, CenterY: float64(tt.BarY + 14)}
+			}
 		}
 
 		tasks = append(tasks, tt)
@@ -415,6 +530,14 @@ func renderTimeline(project *model.Project, minDate, maxDate time.Time, timeline
 		Height:        height,
 		TimelineCells: headerCells,
 		Tasks:         tasks,
+		Arrows:        buildDependencyArrows(rows, anchors, 40),
+	}
+
+	// Status line, only when the status date falls inside the chart
+	if !opts.StatusDate.IsZero() && !opts.StatusDate.Before(minDate) && !opts.StatusDate.After(maxDate) {
+		data.ShowStatusLine = true
+		data.StatusX = dateX(opts.StatusDate)
+		data.StatusLabel = opts.StatusDate.Format("Jan 2")
 	}
 
 	tmpl, err := template.New("timeline").Parse(timelineSVGTemplate)
@@ -0,0 +1,51 @@
+package renderer
+
+import (
+	"time"
+
+	"gantt-gen/baseline"
+	"gantt-gen/model"
+)
+
+const (
+	milestoneColor = "#e74c3c" // Milestone diamonds
+	overdueColor   = "#e67e22" // Unfinished tasks whose end is before the status date
+)
+
+// Options controls optional chart features shared by all renderers
+type Options struct {
+	// StatusDate draws a vertical "status" line and flags overdue tasks; zero disables both
+	StatusDate time.Time
+
+	// GroupBy arranges rows in swimlanes (GroupByAssignee) instead of document order
+	GroupBy string
+
+	// Baseline draws ghost bars at each task's saved dates and labels bars with their finish variance
+	Baseline *baseline.Snapshot
+}
+
+// chartRange returns the dates spanned by the chart: every calculated date,
+// widened to include baseline dates of tasks still in the project
+func chartRange(project *model.Project, opts Options) (time.Time, time.Time, error) {
+	minDate, maxDate, err := scheduleRange(project)
+	if err != nil {
+		return minDate, maxDate, err
+	}
+
+	if opts.Baseline != nil {
+		for _, task := range project.Tasks {
+			dates, ok := opts.Baseline.Tasks[task.Name]
+			if !ok {
+				continue
+			}
+			if dates.Start.Before(minDate) {
+				minDate = dates.Start
+			}
+			if dates.End.After(maxDate) {
+				maxDate = dates.End
+			}
+		}
+	}
+
+	return minDate, maxDate, nil
+}
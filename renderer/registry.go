@@ -0,0 +1,117 @@
+package renderer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"gantt-gen/model"
+)
+
+// Renderer draws a resolved project in one output format
+type Renderer interface {
+	Render(project *model.Project, opts Options) (string, error)
+}
+
+// RendererFunc adapts an ordinary function to the Renderer interface
+type RendererFunc func(project *model.Project, opts Options) (string, error)
+
+// Render calls f(project, opts)
+func (f RendererFunc) Render(project *model.Project, opts Options) (string, error) {
+	return f(project, opts)
+}
+
+// Format is an output format registered with Register
+type Format struct {
+	Name        string   // Used with --format, e.g. "svg"; matched case-insensitively
+	Description string   // One line for --help
+	Extensions  []string // File suffixes that select this format, e.g. ".svg" or ".confluence.html"
+	MIMEType    string   // Content-Type of the output, e.g. "image/svg+xml"
+	Renderer    Renderer
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]Format)
+)
+
+// Register makes a format available by name and file extension. Names are
+// stored in lower case. It panics if the name is empty or already registered,
+// or if the format has no renderer.
+func Register(format Format) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	format.Name = strings.ToLower(format.Name)
+	if format.Name == "" {
+		panic("renderer: Register format with empty name")
+	}
+	if format.Renderer == nil {
+		panic("renderer: Register format " + format.Name + " without a renderer")
+	}
+	if _, dup := registry[format.Name]; dup {
+		panic("renderer: Register called twice for format " + format.Name)
+	}
+	registry[format.Name] = format
+}
+
+// Lookup returns the format registered under name
+func Lookup(name string) (Format, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	format, ok := registry[strings.ToLower(name)]
+	return format, ok
+}
+
+// FormatForPath returns the format whose extension matches the end of path;
+// the longest matching extension wins, so "plan.confluence.html" is Confluence
+// rather than HTML
+func FormatForPath(path string) (Format, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	lower := strings.ToLower(path)
+	var best Format
+	bestLen := 0
+	for _, format := range registry {
+		for _, ext := range format.Extensions {
+			if len(ext) > bestLen && strings.HasSuffix(lower, strings.ToLower(ext)) {
+				best, bestLen = format, len(ext)
+			}
+		}
+	}
+	return best, bestLen > 0
+}
+
+// Formats returns every registered format, sorted by name
+func Formats() []Format {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	formats := make([]Format, 0, len(registry))
+	for _, format := range registry {
+		formats = append(formats, format)
+	}
+	sort.Slice(formats, func(i, j int) bool { return formats[i].Name < formats[j].Name })
+	return formats
+}
+
+// FormatNames returns the registered format names, sorted
+func FormatNames() []string {
+	var names []string
+	for _, format := range Formats() {
+		names = append(names, format.Name)
+	}
+	return names
+}
+
+// Render draws project in the named format
+func Render(name string, project *model.Project, opts Options) (string, error) {
+	format, ok := Lookup(name)
+	if !ok {
+		return "", fmt.Errorf("unknown format %q", name)
+	}
+	return format.Renderer.Render(project, opts)
+}
@@ -0,0 +1,78 @@
+package renderer
+
+import (
+	"strings"
+	"testing"
+
+	"gantt-gen/model"
+)
+
+func TestFormatForPath(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+		ok   bool
+	}{
+		{"chart.svg", "svg", true},
+		{"chart.HTML", "html", true},
+		{"chart.htm", "html", true},
+		{"page.confluence.html", "confluence", true},
+		{"load.utilization.svg", "utilization", true},
+		{"load.utilization.html", "utilization-html", true},
+		{"chart.png", "", false},
+		{"-", "", false},
+	}
+
+	for _, tt := range tests {
+		got, ok := FormatForPath(tt.path)
+		if got.Name != tt.want || ok != tt.ok {
+			t.Errorf("FormatForPath(%q) = %q, %v, want %q, %v", tt.path, got.Name, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestRegister(t *testing.T) {
+	Register(Format{
+		Name:       "Test-Text",
+		Extensions: []string{".test.txt"},
+		MIMEType:   "text/plain",
+		Renderer: RendererFunc(func(project *model.Project, opts Options) (string, error) {
+			return "tasks: " + project.Tasks[0].Name, nil
+		}),
+	})
+	t.Cleanup(func() {
+		registryMu.Lock()
+		defer registryMu.Unlock()
+		delete(registry, "test-text")
+	})
+
+	if format, ok := FormatForPath("plan.test.txt"); !ok || format.Name != "test-text" {
+		t.Errorf("FormatForPath() = %q, %v, want the registered format", format.Name, ok)
+	}
+	if format, ok := Lookup("TEST-text"); !ok || format.MIMEType != "text/plain" {
+		t.Errorf("Lookup() = %+v, %v, want the format whatever the case", format, ok)
+	}
+	if !strings.Contains(strings.Join(FormatNames(), ","), "test-text") {
+		t.Errorf("FormatNames() = %v, want it to include test-text", FormatNames())
+	}
+
+	out, err := Render("test-text", &model.Project{Tasks: []model.Task{{Name: "Design"}}}, Options{})
+	if err != nil || out != "tasks: Design" {
+		t.Errorf("Render() = %q, %v", out, err)
+	}
+
+	if _, err := Render("no-such-format", &model.Project{}, Options{}); err == nil {
+		t.Error("Render() expected error for an unknown format")
+	}
+
+	for _, name := range []string{"svg", "SVG"} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Register(%q) should panic on a duplicate name", name)
+				}
+			}()
+			Register(Format{Name: name, Renderer: RendererFunc(RenderSVGWithOptions)})
+		}()
+	}
+}
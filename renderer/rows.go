@@ -0,0 +1,79 @@
+package renderer
+
+import (
+	"fmt"
+	"sort"
+
+	"gantt-gen/model"
+)
+
+// Row grouping modes for Options.GroupBy
+const (
+	GroupByNone     = ""
+	GroupByAssignee = "assignee"
+)
+
+const unassignedLane = "Unassigned"
+
+// chartRow is one row of the chart: a task, or a swimlane header when Task is nil.
+// Lane names the swimlane the row belongs to, if any.
+type chartRow struct {
+	Task *model.Task
+	Lane string
+}
+
+// layoutRows orders the chart rows according to opts.GroupBy
+func layoutRows(project *model.Project, opts Options) ([]chartRow, error) {
+	switch opts.GroupBy {
+	case GroupByNone:
+		rows := make([]chartRow, 0, len(project.Tasks))
+		for i := range project.Tasks {
+			rows = append(rows, chartRow{Task: &project.Tasks[i]})
+		}
+		return rows, nil
+
+	case GroupByAssignee:
+		return assigneeRows(project), nil
+
+	default:
+		return nil, fmt.Errorf("unknown group-by mode: %s", opts.GroupBy)
+	}
+}
+
+// assigneeRows lays out one swimlane per resource, in name order with
+// unassigned work last; tasks with several resources appear in each lane
+func assigneeRows(project *model.Project) []chartRow {
+	lanes := make(map[string][]*model.Task)
+	var names []string
+
+	for i := range project.Tasks {
+		task := &project.Tasks[i]
+
+		resources := task.Resources
+		if len(resources) == 0 {
+			resources = []string{unassignedLane}
+		}
+
+		for _, resource := range resources {
+			if _, ok := lanes[resource]; !ok && resource != unassignedLane {
+				names = append(names, resource)
+			}
+			lanes[resource] = append(lanes[resource], task)
+		}
+	}
+
+	sort.Strings(names)
+	if _, ok := lanes[unassignedLane]; ok {
+		names = append(names, unassignedLane)
+	}
+
+	var rows []chartRow
+	for _, name := range names {
+		rows = append(rows, chartRow{Lane: name})
+		for _, task := range lanes[name] {
+			rows = append(rows, chartRow{Task: task, Lane: name})
+		}
+	}
+
+	return rows
+}
@@ -0,0 +1,79 @@
+package renderer
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"gantt-gen/model"
+)
+
+func TestLayoutRows_Assignee(t *testing.T) {
+	project := &model.Project{
+		Tasks: []model.Task{
+			{Name: "Design", Resources: []string{"Bob"}},
+			{Name: "Backend", Resources: []string{"Alice", "Bob"}},
+			{Name: "Launch", IsMilestone: true},
+			{Name: "Frontend", Resources: []string{"Alice"}},
+		},
+	}
+
+	rows, err := layoutRows(project, Options{GroupBy: GroupByAssignee})
+	if err != nil {
+		t.Fatalf("layoutRows() error = %v", err)
+	}
+
+	want := []string{
+		"lane:Alice", "Backend", "Frontend",
+		"lane:Bob", "Design", "Backend",
+		"lane:Unassigned", "Launch",
+	}
+
+	var got []string
+	for _, row := range rows {
+		if row.Task == nil {
+			got = append(got, "lane:"+row.Lane)
+		} else {
+			got = append(got, row.Task.Name)
+		}
+	}
+
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("rows = %v, want %v", got, want)
+	}
+}
+
+func TestLayoutRows_UnknownMode(t *testing.T) {
+	_, err := layoutRows(&model.Project{}, Options{GroupBy: "team"})
+	if err == nil {
+		t.Error("layoutRows() expected error for unknown group-by mode")
+	}
+}
+
+func TestRenderSVG_GroupByAssignee(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)
+
+	project := &model.Project{
+		Name: "Test Project",
+		Tasks: []model.Task{
+			{Name: "Task A", Level: 2, CalculatedStart: &start, CalculatedEnd: &end, Resources: []string{"Alice"}},
+		},
+	}
+
+	svg, err := RenderSVGWithOptions(project, Options{GroupBy: GroupByAssignee})
+	if err != nil {
+		t.Fatalf("RenderSVGWithOptions() error = %v", err)
+	}
+	if !strings.Contains(svg, `class="lane-row"`) || !strings.Contains(svg, "Alice") {
+		t.Error("SVG should draw a swimlane header for each assignee")
+	}
+
+	html, err := RenderHTMLWithOptions(project, Options{GroupBy: GroupByAssignee})
+	if err != nil {
+		t.Fatalf("RenderHTMLWithOptions() error = %v", err)
+	}
+	if !strings.Contains(html, "Alice") {
+		t.Error("HTML should draw a swimlane header for each assignee")
+	}
+}
@@ -12,6 +12,8 @@ import (
 
 const svgTemplate = `<?xml version="1.0" encoding="UTF-8"?>
 <svg xmlns="http://www.w3.org/2000/svg" width="{{.Width}}" height="{{.Height}}" viewBox="0 0 {{.Width}} {{.Height}}">
+    ` + arrowDefs + `
+
     <!-- Background -->
     <rect width="{{.Width}}" height="{{.Height}}" fill="#ffffff"/>
 
@@ -33,6 +35,14 @@ const svgTemplate = `<?xml version="1.0" encoding="UTF-8"?>
 
     <!-- Tasks -->
     {{range $i, $task := .Tasks}}
+    {{if $task.IsLane}}
+    <g class="lane-row">
+        <rect x="20" y="{{$task.Y}}" width="{{$.LaneWidth}}" height="40" fill="#f0f4f8" stroke="#eee"/>
+        <text x="30" y="{{$task.TextY}}" font-family="Arial, sans-serif" font-size="13" font-weight="bold" fill="#333">
+            {{$task.DisplayName}}
+        </text>
+    </g>
+    {{else}}
     <g class="task-row">
         <!-- Task name -->
         <rect x="20" y="{{$task.Y}}" width="200" height="40" fill="none" stroke="#eee"/>
@@ -45,23 +55,52 @@ const svgTemplate = `<?xml version="1.0" encoding="UTF-8"?>
         <!-- Timeline -->
         <rect x="220" y="{{$task.Y}}" width="{{$.TimelineWidth}}" height="40" fill="none" stroke="#eee"/>
 
+        {{if $task.ShowBaseline}}
+        <rect class="baseline" x="{{$task.BaselineX}}" y="{{$task.BaselineY}}" width="{{$task.BaselineWidth}}" height="4" fill="#95a5a6" rx="1"/>
+        {{end}}
+
         <!-- Task bar or milestone -->
         {{if $task.IsMilestone}}
         <rect x="{{$task.BarX}}" y="{{$task.MilestoneY}}" width="10" height="10"
-              fill="#e74c3c" transform="rotate(45 {{$task.MilestoneCenterX}} {{$task.MilestoneCenterY}})"/>
+              fill="{{$task.MilestoneColor}}"{{if $task.IsCritical}} stroke="#7b1f1a" stroke-width="2"{{end}} transform="rotate(45 {{$task.MilestoneCenterX}} {{$task.MilestoneCenterY}})"/>
+        {{else if $task.IsSummary}}
+        <path d="{{$task.SummaryPath}}" fill="{{$task.SummaryColor}}"/>
         {{else}}
         <rect x="{{$task.BarX}}" y="{{$task.BarY}}" width="{{$task.BarWidth}}" height="28"
               fill="{{$task.Color}}" rx="3"/>
+        {{if gt $task.Progress 0}}
+        <rect x="{{$task.BarX}}" y="{{$task.BarY}}" width="{{$task.ProgressWidth}}" height="28"
+              fill="#000000" fill-opacity="0.25" rx="3"/>
+        {{end}}
         <text x="{{$task.DateX}}" y="{{$task.DateY}}"
               font-family="Arial, sans-serif" font-size="10" fill="white">
             {{$task.DateRange}}
         </text>
         {{end}}
+        {{if $task.ShowDeadline}}
+        <path class="deadline" d="{{$task.DeadlinePath}}" fill="{{$task.DeadlineColor}}" stroke="{{$task.DeadlineColor}}" stroke-width="1.5"/>
+        {{end}}
     </g>
     {{end}}
+    {{end}}
+
+    <!-- Dependency arrows -->
+    {{range $arrow := .Arrows}}
+    <path d="{{$arrow.Path}}" fill="none" stroke="{{$arrow.Color}}" stroke-width="1.5" marker-end="url(#{{$arrow.Marker}})"/>
+    {{end}}
+
+    <!-- Status date -->
+    {{if .ShowStatusLine}}
+    <line x1="{{.StatusX}}" y1="50" x2="{{.StatusX}}" y2="{{.StatusLineEnd}}" stroke="#e67e22" stroke-width="2" stroke-dasharray="6,4"/>
+    <text x="{{.StatusX}}" y="45" font-family="Arial, sans-serif" font-size="10" fill="#e67e22" text-anchor="middle">
+        {{.StatusLabel}}
+    </text>
+    {{end}}
 </svg>
 `
 
+const criticalColor = "#d64541" // Bars on the critical path
+
 const (
 	maxTaskNameWidth   = 170 // Reserve 30px for indentation/padding
 	avgCharWidthPixels = 7.0 // Average character width in Arial 13px
@@ -97,6 +136,53 @@ func truncateTaskName(name string, level int) string {
 	return ellipsis
 }
 
+// barColor picks the bar fill for a task: missed deadlines, overdue and critical tasks stand out,
+// others are colored by level
+func barColor(task model.Task, opts Options) string {
+	if task.MissesDeadline() {
+		return missedDeadlineColor
+	}
+	if !opts.StatusDate.IsZero() && task.IsOverdue(opts.StatusDate) {
+		return overdueColor
+	}
+	if task.IsCritical {
+		return criticalColor
+	}
+
+	switch task.Level {
+	case 2:
+		return "#4a90e2"
+	case 3:
+		return "#7eb0e8"
+	case 4:
+		return "#a8c9ed"
+	default:
+		return "#4a90e2"
+	}
+}
+
+// summaryColor is the bracket color for summary tasks
+func summaryColor(task model.Task) string {
+	if task.MissesDeadline() {
+		return missedDeadlineColor
+	}
+	if task.IsCritical {
+		return criticalColor
+	}
+	return "#2c3e50"
+}
+
+// summaryPath draws a summary bracket: a thin bar with downward points at both
+// ends, spanning the same extent as a regular bar at (x, y)
+func summaryPath(x, y, width float64) string {
+	tip := 6.0
+	if width < 2*tip {
+		tip = width / 2
+	}
+	return fmt.Sprintf("M %.2f %.2f H %.2f V %.2f L %.2f %.2f H %.2f L %.2f %.2f Z",
+		x, y+4, x+width, y+22, x+width-tip, y+14, x+tip, x, y+22)
+}
+
 type svgTask struct {
 	model.Task
 	DisplayName      string  // Truncated name for display
@@ -106,6 +192,7 @@ type svgTask struct {
 	BarX             float64
 	BarY             int
 	BarWidth         float64
+	ProgressWidth    float64 // Width of the completed portion of the bar
 	MilestoneY       int
 	MilestoneCenterX float64
 	MilestoneCenterY int
@@ -113,6 +200,17 @@ type svgTask struct {
 	DateY            int
 	DateRange        string
 	Color            string
+	MilestoneColor   string
+	IsLane           bool // Swimlane header row; DisplayName holds the lane label
+	SummaryPath      string
+	SummaryColor     string
+	ShowDeadline     bool
+	DeadlinePath     string
+	DeadlineColor    string
+	ShowBaseline     bool
+	BaselineX        float64
+	BaselineY        int
+	BaselineWidth    float64
 }
 
 type timelineCell struct {
@@ -126,8 +224,15 @@ type svgData struct {
 	Width         int
 	Height        int
 	TimelineWidth int
+	LaneWidth     int
 	Tasks         []svgTask
 	TimelineCells []timelineCell
+	Arrows        []dependencyArrow
+
+	ShowStatusLine bool
+	StatusX        float64
+	StatusLineEnd  int
+	StatusLabel    string
 }
 
 // generateTimelineCells creates timeline header cells (months or weeks)
@@ -232,25 +337,27 @@ func generateTimelineCells(minDate, maxDate time.Time, timelineWidth int, milest
 	return cells
 }
 
+func init() {
+	Register(Format{
+		Name:        "svg",
+		Description: "Standalone SVG Gantt chart",
+		Extensions:  []string{".svg"},
+		MIMEType:    "image/svg+xml",
+		Renderer:    RendererFunc(RenderSVGWithOptions),
+	})
+}
+
 // RenderSVG generates an SVG Gantt chart
 func RenderSVG(project *model.Project) (string, error) {
-	// Find date range
-	var minDate, maxDate time.Time
-	for _, task := range project.Tasks {
-		if task.CalculatedStart != nil {
-			if minDate.IsZero() || task.CalculatedStart.Before(minDate) {
-				minDate = *task.CalculatedStart
-			}
-		}
-		if task.CalculatedEnd != nil {
-			if maxDate.IsZero() || task.CalculatedEnd.After(maxDate) {
-				maxDate = *task.CalculatedEnd
-			}
-		}
-	}
+	return RenderSVGWithOptions(project, Options{})
+}
 
-	if minDate.IsZero() || maxDate.IsZero() {
-		return "", fmt.Errorf("no tasks with calculated dates")
+// RenderSVGWithOptions generates an SVG Gantt chart, drawing the optional features enabled in opts
+func RenderSVGWithOptions(project *model.Project, opts Options) (string, error) {
+	// Find date range
+	minDate, maxDate, err := chartRange(project, opts)
+	if err != nil {
+		return "", err
 	}
 
 	totalDays := maxDate.Sub(minDate).Hours() / 24
@@ -269,17 +376,40 @@ func RenderSVG(project *model.Project) (string, error) {
 	milestonePadding := milestoneRadius * 2 // Total padding needed
 	effectiveTimelineWidth := float64(timelineWidth) - milestonePadding
 
+	// dateX maps a date to its x position on the timeline
+	dateX := func(date time.Time) float64 {
+		offset := date.Sub(minDate).Hours() / 24
+		return 220 + (offset/totalDays)*effectiveTimelineWidth + (milestoneRadius - 5.0)
+	}
+
 	rowHeight := 40
 	headerHeight := 90
 
 	// Generate timeline header cells
 	timelineCells := generateTimelineCells(minDate, maxDate, timelineWidth, milestonePadding)
 
+	rows, err := layoutRows(project, opts)
+	if err != nil {
+		return "", err
+	}
+
 	// Build SVG tasks
 	var svgTasks []svgTask
-	for i, task := range project.Tasks {
+	anchors := make(map[anchorKey]barAnchor)
+	for i, row := range rows {
 		y := headerHeight + (i * rowHeight)
 
+		if row.Task == nil {
+			svgTasks = append(svgTasks, svgTask{
+				IsLane:      true,
+				DisplayName: truncateTaskName(row.Lane, 2),
+				Y:           y,
+				TextY:       y + 25,
+			})
+			continue
+		}
+		task := *row.Task
+
 		// Truncate name for display
 		displayName := truncateTaskName(task.Name, task.Level)
 
@@ -297,16 +427,17 @@ func RenderSVG(project *model.Project) (string, error) {
 			st.DisplayName = displayName
 		}
 
-		// Color by level
-		switch task.Level {
-		case 2:
-			st.Color = "#4a90e2"
-		case 3:
-			st.Color = "#7eb0e8"
-		case 4:
-			st.Color = "#a8c9ed"
-		default:
-			st.Color = "#4a90e2"
+		st.Color = barColor(task, opts)
+		st.MilestoneColor = milestoneColor
+		if !opts.StatusDate.IsZero() && task.IsOverdue(opts.StatusDate) {
+			st.MilestoneColor = overdueColor
+		}
+		st.DeadlinePath, st.ShowDeadline = deadlineFlag(task, minDate, maxDate, y, dateX)
+		st.BaselineX, st.BaselineWidth, st.ShowBaseline = baselineBar(task, opts, dateX)
+		st.BaselineY = y + 35
+		st.DeadlineColor = deadlineColor
+		if task.MissesDeadline() {
+			st.DeadlineColor = missedDeadlineColor
 		}
 
 		if task.CalculatedStart != nil && task.CalculatedEnd != nil {
@@ -336,12 +467,31 @@ func RenderSVG(project *model.Project) (string, error) {
 			st.DateRange = fmt.Sprintf("%s - %s",
 				task.CalculatedStart.Format("Jan 2"),
 				task.CalculatedEnd.Format("Jan 2"))
+
+			if task.IsSummary {
+				st.SummaryPath = summaryPath(st.BarX, float64(st.BarY), barWidth)
+				st.SummaryColor = summaryColor(task)
+			}
+
+			st.DateRange += varianceLabel(project, row.Task, opts)
+
+			if task.Progress > 0 {
+				st.ProgressWidth = barWidth * float64(task.Progress) / 100
+				st.DateRange += fmt.Sprintf(" (%d%%)", task.Progress)
+			}
+
+			key := anchorKey{row.Lane, task.Name}
+			if task.IsMilestone {
+				anchors[key] = milestoneAnchor(st.MilestoneCenterX, float64(st.MilestoneCenterY))
+			} else {
+				anchors[key] = barAnchor{StartX: st.BarX, EndX: st.BarX + st.BarWidth, CenterY: float64(st.BarY + 14)}
+			}
 		}
 
 		svgTasks = append(svgTasks, st)
 	}
 
-	totalHeight := headerHeight + (len(project.Tasks) * rowHeight) + 20 // just add minimal bottom padding
+	totalHeight := headerHeight + (len(rows) * rowHeight) + 20 // just add minimal bottom padding
 	totalWidth := 220 + timelineWidth + 20 // task column + timeline + minimal right padding
 
 	data := svgData{
@@ -349,8 +499,18 @@ func RenderSVG(project *model.Project) (string, error) {
 		Width:         totalWidth,
 		Height:        totalHeight,
 		TimelineWidth: timelineWidth,
+		LaneWidth:     200 + timelineWidth,
 		Tasks:         svgTasks,
 		TimelineCells: timelineCells,
+		Arrows:        buildDependencyArrows(rows, anchors, rowHeight),
+	}
+
+	// Status line, only when the status date falls inside the chart
+	if !opts.StatusDate.IsZero() && !opts.StatusDate.Before(minDate) && !opts.StatusDate.After(maxDate) {
+		data.ShowStatusLine = true
+		data.StatusX = dateX(opts.StatusDate)
+		data.StatusLineEnd = totalHeight - 20
+		data.StatusLabel = opts.StatusDate.Format("Jan 2")
 	}
 
 	tmpl, err := template.New("gantt").Parse(svgTemplate)
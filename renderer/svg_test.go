@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"gantt-gen/baseline"
 	"gantt-gen/model"
 )
 
@@ -75,3 +76,238 @@ func TestRenderSVG_LongTaskName(t *testing.T) {
 		t.Error("SVG should contain start of task name")
 	}
 }
+
+func TestRenderSVG_CriticalTask(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)
+
+	project := &model.Project{
+		Name: "Test Project",
+		Tasks: []model.Task{
+			{
+				Name:            "Task A",
+				Level:           2,
+				CalculatedStart: &start,
+				CalculatedEnd:   &end,
+				IsCritical:      true,
+			},
+		},
+	}
+
+	svg, err := RenderSVG(project)
+	if err != nil {
+		t.Fatalf("RenderSVG() error = %v", err)
+	}
+
+	if !strings.Contains(svg, criticalColor) {
+		t.Error("SVG should draw critical tasks in the critical color")
+	}
+
+	html, err := RenderHTML(project)
+	if err != nil {
+		t.Fatalf("RenderHTML() error = %v", err)
+	}
+
+	if !strings.Contains(html, criticalColor) {
+		t.Error("HTML should draw critical tasks in the critical color")
+	}
+}
+
+func TestRenderSVG_Progress(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)
+
+	project := &model.Project{
+		Name: "Test Project",
+		Tasks: []model.Task{
+			{
+				Name:            "Task A",
+				Level:           2,
+				CalculatedStart: &start,
+				CalculatedEnd:   &end,
+				Progress:        40,
+			},
+		},
+	}
+
+	svg, err := RenderSVG(project)
+	if err != nil {
+		t.Fatalf("RenderSVG() error = %v", err)
+	}
+
+	if !strings.Contains(svg, "(40%)") {
+		t.Error("SVG label should include the percent complete")
+	}
+	if !strings.Contains(svg, `fill-opacity="0.25"`) {
+		t.Error("SVG should draw the completed portion of the bar")
+	}
+
+	html, err := RenderHTML(project)
+	if err != nil {
+		t.Fatalf("RenderHTML() error = %v", err)
+	}
+
+	if !strings.Contains(html, "(40%)") {
+		t.Error("HTML label should include the percent complete")
+	}
+}
+
+func TestRenderSVG_StatusDate(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)
+	later := time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC)
+
+	project := &model.Project{
+		Name: "Test Project",
+		Tasks: []model.Task{
+			{Name: "Task A", Level: 2, CalculatedStart: &start, CalculatedEnd: &end, Progress: 50},
+			{Name: "Task B", Level: 2, CalculatedStart: &end, CalculatedEnd: &later},
+		},
+	}
+
+	opts := Options{StatusDate: time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)}
+
+	svg, err := RenderSVGWithOptions(project, opts)
+	if err != nil {
+		t.Fatalf("RenderSVGWithOptions() error = %v", err)
+	}
+	if !strings.Contains(svg, "stroke-dasharray") || !strings.Contains(svg, "Jan 10") {
+		t.Error("SVG should draw the status date line")
+	}
+	if !strings.Contains(svg, overdueColor) {
+		t.Error("SVG should highlight the overdue task")
+	}
+
+	html, err := RenderHTMLWithOptions(project, opts)
+	if err != nil {
+		t.Fatalf("RenderHTMLWithOptions() error = %v", err)
+	}
+	if !strings.Contains(html, "stroke-dasharray") || !strings.Contains(html, overdueColor) {
+		t.Error("HTML should draw the status date line and highlight the overdue task")
+	}
+
+	// Outside the chart range there is no line and nothing is overdue
+	svg, err = RenderSVGWithOptions(project, Options{StatusDate: start.AddDate(0, 0, -7)})
+	if err != nil {
+		t.Fatalf("RenderSVGWithOptions() error = %v", err)
+	}
+	if strings.Contains(svg, "stroke-dasharray") || strings.Contains(svg, overdueColor) {
+		t.Error("SVG should not draw a status line before the chart starts")
+	}
+}
+
+func TestRenderSVG_SummaryTask(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)
+
+	project := &model.Project{
+		Name: "Test Project",
+		Tasks: []model.Task{
+			{Name: "Phase", Level: 2, CalculatedStart: &start, CalculatedEnd: &end, IsSummary: true},
+			{Name: "Task A", Level: 3, CalculatedStart: &start, CalculatedEnd: &end},
+		},
+	}
+
+	svg, err := RenderSVG(project)
+	if err != nil {
+		t.Fatalf("RenderSVG() error = %v", err)
+	}
+	if !strings.Contains(svg, `fill="#2c3e50"`) {
+		t.Error("SVG should draw summary tasks as a bracket")
+	}
+
+	html, err := RenderHTML(project)
+	if err != nil {
+		t.Fatalf("RenderHTML() error = %v", err)
+	}
+	if !strings.Contains(html, `fill="#2c3e50"`) {
+		t.Error("HTML should draw summary tasks as a bracket")
+	}
+}
+
+func TestRenderSVG_Deadline(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	met := time.Date(2024, 1, 12, 0, 0, 0, 0, time.UTC)
+	missed := time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC)
+	later := time.Date(2024, 1, 19, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		deadline   time.Time
+		wantFlag   bool
+		wantMissed bool
+	}{
+		{"deadline met", met, true, false},
+		{"deadline missed", missed, true, true},
+		{"deadline outside chart", later.AddDate(0, 1, 0), false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			project := &model.Project{
+				Name: "Test Project",
+				Tasks: []model.Task{
+					{Name: "Task A", Level: 2, CalculatedStart: &start, CalculatedEnd: &end, Deadline: &tt.deadline},
+					{Name: "Task B", Level: 2, CalculatedStart: &end, CalculatedEnd: &later},
+				},
+			}
+
+			svg, err := RenderSVG(project)
+			if err != nil {
+				t.Fatalf("RenderSVG() error = %v", err)
+			}
+			html, err := RenderHTML(project)
+			if err != nil {
+				t.Fatalf("RenderHTML() error = %v", err)
+			}
+
+			for format, out := range map[string]string{"SVG": svg, "HTML": html} {
+				if got := strings.Contains(out, `class="deadline"`); got != tt.wantFlag {
+					t.Errorf("%s deadline flag drawn = %v, want %v", format, got, tt.wantFlag)
+				}
+				if got := strings.Contains(out, missedDeadlineColor); got != tt.wantMissed {
+					t.Errorf("%s missed deadline color = %v, want %v", format, got, tt.wantMissed)
+				}
+			}
+		})
+	}
+}
+
+func TestRenderSVG_Baseline(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 9, 0, 0, 0, 0, time.UTC)
+
+	project := &model.Project{
+		Name: "Test Project",
+		Tasks: []model.Task{
+			{Name: "Task A", Level: 2, CalculatedStart: &start, CalculatedEnd: &end},
+			{Name: "Task B", Level: 2, CalculatedStart: &start, CalculatedEnd: &end},
+		},
+	}
+
+	// Task A slipped two business days; Task B is new since the baseline
+	opts := Options{Baseline: &baseline.Snapshot{
+		Tasks: map[string]baseline.Dates{
+			"Task A": {Start: start, End: time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)},
+		},
+	}}
+
+	svg, err := RenderSVGWithOptions(project, opts)
+	if err != nil {
+		t.Fatalf("RenderSVGWithOptions() error = %v", err)
+	}
+	html, err := RenderHTMLWithOptions(project, opts)
+	if err != nil {
+		t.Fatalf("RenderHTMLWithOptions() error = %v", err)
+	}
+
+	for format, out := range map[string]string{"SVG": svg, "HTML": html} {
+		if got := strings.Count(out, `class="baseline"`); got != 1 {
+			t.Errorf("%s ghost bars = %d, want 1", format, got)
+		}
+		if !strings.Contains(out, "[+2d]") {
+			t.Errorf("%s should label the finish variance", format)
+		}
+	}
+}
@@ -0,0 +1,400 @@
+package renderer
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"text/template"
+	"time"
+
+	"gantt-gen/calendar"
+	"gantt-gen/model"
+)
+
+const utilizationSVGTemplate = `<svg xmlns="http://www.w3.org/2000/svg" width="{{.Width}}" height="{{.Height}}" viewBox="0 0 {{.Width}} {{.Height}}">
+    <rect width="{{.Width}}" height="{{.Height}}" fill="#ffffff"/>
+
+    <!-- Title -->
+    <text x="20" y="30" font-family="Arial, sans-serif" font-size="20" font-weight="bold" fill="#333">
+        {{.Name}} - Resource Utilization
+    </text>
+
+    <!-- Week headers -->
+    <rect x="20" y="50" width="200" height="40" fill="none" stroke="#eee"/>
+    {{range $week := .Weeks}}
+    <rect x="{{$week.X}}" y="50" width="{{$.WeekWidth}}" height="40" fill="none" stroke="#eee"/>
+    <text x="{{$week.X}}" y="75" font-family="Arial, sans-serif" font-size="11" font-weight="600" fill="#333" dx="5">
+        {{$week.Label}}
+    </text>
+    {{end}}
+
+    <!-- Resources -->
+    {{range $row := .Rows}}
+    <g class="resource-row">
+        <rect x="20" y="{{$row.Y}}" width="200" height="{{$.RowHeight}}" fill="none" stroke="#eee"/>
+        <text x="30" y="{{$row.TextY}}" font-family="Arial, sans-serif" font-size="13" fill="#333">
+            {{$row.DisplayName}}
+        </text>
+        <rect x="220" y="{{$row.Y}}" width="{{$.ChartWidth}}" height="{{$.RowHeight}}" fill="none" stroke="#eee"/>
+        {{range $bar := $row.Bars}}
+        {{if gt $bar.Allocated 0}}
+        <rect x="{{$bar.X}}" y="{{$bar.Y}}" width="{{$bar.Width}}" height="{{$bar.Height}}" fill="{{$bar.Color}}" rx="2"/>
+        <text x="{{$bar.LabelX}}" y="{{$bar.LabelY}}" font-family="Arial, sans-serif" font-size="10" fill="#333" text-anchor="middle">
+            {{$bar.Allocated}}/{{$bar.Capacity}}
+        </text>
+        {{end}}
+        <line x1="{{$bar.X}}" y1="{{$bar.CapacityY}}" x2="{{$bar.CapacityX2}}" y2="{{$bar.CapacityY}}" stroke="#999" stroke-width="1" stroke-dasharray="3,3"/>
+        {{end}}
+    </g>
+    {{end}}
+
+    <!-- Status date -->
+    {{if .ShowStatusLine}}
+    <line x1="{{.StatusX}}" y1="50" x2="{{.StatusX}}" y2="{{.StatusLineEnd}}" stroke="#e67e22" stroke-width="2" stroke-dasharray="6,4"/>
+    <text x="{{.StatusX}}" y="45" font-family="Arial, sans-serif" font-size="10" fill="#e67e22" text-anchor="middle">
+        {{.StatusLabel}}
+    </text>
+    {{end}}
+</svg>
+`
+
+const utilizationHTMLTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>{{.Name}} - Resource Utilization</title>
+    <style>
+        body {
+            margin: 0;
+            font-family: Arial, sans-serif;
+            background: #f5f5f5;
+        }
+
+        .chart {
+            margin: 20px;
+            overflow-x: auto;
+            background: white;
+            border: 1px solid #e0e0e0;
+        }
+
+        svg {
+            display: block;
+        }
+    </style>
+</head>
+<body>
+    <div class="chart">
+        {{.SVG}}
+    </div>
+</body>
+</html>
+`
+
+const (
+	overallocatedColor = "#e74c3c"
+	allocatedColor     = "#4a90e2"
+	utilizationWeekPx  = 90
+	utilizationRowPx   = 80
+)
+
+// resourceWeek is the load of one resource in one calendar week
+type resourceWeek struct {
+	Start     time.Time // Monday of the week
+	Allocated int       // Business days booked across all tasks, on the default calendar
+	Capacity  int       // Business days available on the default calendar
+}
+
+// resourceLoad is a resource's weekly allocation over the whole schedule
+type resourceLoad struct {
+	Resource string
+	Weeks    []resourceWeek
+}
+
+type utilizationWeekHeader struct {
+	X     float64
+	Label string
+}
+
+type utilizationBar struct {
+	X          float64
+	Y          float64
+	Width      float64
+	Height     float64
+	Color      string
+	Allocated  int
+	Capacity   int
+	LabelX     float64
+	LabelY     float64
+	CapacityY  float64
+	CapacityX2 float64
+}
+
+type utilizationRow struct {
+	Y           int
+	TextY       int
+	DisplayName string
+	Bars        []utilizationBar
+}
+
+type utilizationData struct {
+	Name       string
+	Width      int
+	Height     int
+	WeekWidth  int
+	RowHeight  int
+	ChartWidth int
+	Weeks      []utilizationWeekHeader
+	Rows       []utilizationRow
+
+	ShowStatusLine bool
+	StatusX        float64
+	StatusLineEnd  int
+	StatusLabel    string
+}
+
+// computeUtilization counts booked business days per resource per week from the resolved schedule.
+// Resources work the default calendar, so it decides both which booked days
+// count and how many days a week has, whatever calendar each task uses.
+func computeUtilization(project *model.Project) ([]resourceLoad, error) {
+	minDate, maxDate, err := scheduleRange(project)
+	if err != nil {
+		return nil, err
+	}
+
+	// Weeks run Monday to Sunday
+	weekday := int(minDate.Weekday())
+	if weekday == 0 {
+		weekday = 7
+	}
+	firstWeek := minDate.AddDate(0, 0, 1-weekday)
+
+	var weekStarts []time.Time
+	for week := firstWeek; !week.After(maxDate); week = week.AddDate(0, 0, 7) {
+		weekStarts = append(weekStarts, week)
+	}
+
+	defaultCal := project.DefaultCalendar()
+
+	loads := make(map[string][]resourceWeek)
+	for i := range project.Tasks {
+		task := &project.Tasks[i]
+		if task.CalculatedStart == nil || task.CalculatedEnd == nil || task.IsSummary {
+			continue
+		}
+
+		for _, resource := range task.Resources {
+			weeks, ok := loads[resource]
+			if !ok {
+				weeks = make([]resourceWeek, len(weekStarts))
+				for w, start := range weekStarts {
+					weeks[w] = resourceWeek{Start: start, Capacity: businessDaysInWeek(start, defaultCal)}
+				}
+				loads[resource] = weeks
+			}
+
+			// A task works the business days after its start up to and including its end
+			for day := task.CalculatedStart.AddDate(0, 0, 1); !day.After(*task.CalculatedEnd); day = day.AddDate(0, 0, 1) {
+				if !calendar.IsBusinessDay(day, defaultCal) {
+					continue
+				}
+				w := int(day.Sub(firstWeek).Hours() / 24 / 7)
+				if w >= 0 && w < len(weeks) {
+					weeks[w].Allocated++
+				}
+			}
+		}
+	}
+
+	var names []string
+	for name := range loads {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var result []resourceLoad
+	for _, name := range names {
+		result = append(result, resourceLoad{Resource: name, Weeks: loads[name]})
+	}
+
+	return result, nil
+}
+
+func businessDaysInWeek(monday time.Time, cal *model.Calendar) int {
+	return calendar.BusinessDaysBetween(monday.AddDate(0, 0, -1), monday.AddDate(0, 0, 6), cal)
+}
+
+// scheduleRange returns the earliest calculated start and latest calculated end
+func scheduleRange(project *model.Project) (time.Time, time.Time, error) {
+	var minDate, maxDate time.Time
+	for _, task := range project.Tasks {
+		if task.CalculatedStart != nil {
+			if minDate.IsZero() || task.CalculatedStart.Before(minDate) {
+				minDate = *task.CalculatedStart
+			}
+		}
+		if task.CalculatedEnd != nil {
+			if maxDate.IsZero() || task.CalculatedEnd.After(maxDate) {
+				maxDate = *task.CalculatedEnd
+			}
+		}
+	}
+
+	if minDate.IsZero() || maxDate.IsZero() {
+		return minDate, maxDate, fmt.Errorf("no tasks with calculated dates")
+	}
+
+	return minDate, maxDate, nil
+}
+
+func init() {
+	Register(Format{
+		Name:        "utilization",
+		Description: "SVG histogram of booked business days per resource per week (no baseline or swimlanes)",
+		Extensions:  []string{".utilization.svg"},
+		MIMEType:    "image/svg+xml",
+		Renderer:    RendererFunc(RenderUtilizationSVG),
+	})
+	Register(Format{
+		Name:        "utilization-html",
+		Description: "Resource utilization histogram as an HTML page (no baseline or swimlanes)",
+		Extensions:  []string{".utilization.html"},
+		MIMEType:    "text/html",
+		Renderer:    RendererFunc(RenderUtilizationHTML),
+	})
+}
+
+// RenderUtilizationSVG generates an SVG histogram of allocated business days per resource per week.
+// Of opts only StatusDate applies, drawing the status line; Baseline and GroupBy
+// are ignored since the histogram has no task bars or task rows.
+func RenderUtilizationSVG(project *model.Project, opts Options) (string, error) {
+	loads, err := computeUtilization(project)
+	if err != nil {
+		return "", err
+	}
+	if len(loads) == 0 {
+		return "", fmt.Errorf("no tasks with assignees")
+	}
+
+	// Scale every row to the busiest week so rows are comparable
+	scale := 5
+	for _, load := range loads {
+		for _, week := range load.Weeks {
+			if week.Allocated > scale {
+				scale = week.Allocated
+			}
+			if week.Capacity > scale {
+				scale = week.Capacity
+			}
+		}
+	}
+
+	headerHeight := 90
+	numWeeks := len(loads[0].Weeks)
+	chartWidth := numWeeks * utilizationWeekPx
+	plotHeight := float64(utilizationRowPx - 25) // Leave room for the value labels
+
+	var weeks []utilizationWeekHeader
+	for w, week := range loads[0].Weeks {
+		weeks = append(weeks, utilizationWeekHeader{
+			X:     float64(220 + w*utilizationWeekPx),
+			Label: week.Start.Format("Jan 2"),
+		})
+	}
+
+	var rows []utilizationRow
+	for r, load := range loads {
+		y := headerHeight + r*utilizationRowPx
+		baseline := float64(y + utilizationRowPx - 5)
+
+		row := utilizationRow{
+			Y:           y,
+			TextY:       y + utilizationRowPx/2 + 5,
+			DisplayName: truncateTaskName(load.Resource, 2),
+		}
+
+		for w, week := range load.Weeks {
+			x := float64(220+w*utilizationWeekPx) + 15
+			width := float64(utilizationWeekPx - 30)
+			height := plotHeight * float64(week.Allocated) / float64(scale)
+
+			color := allocatedColor
+			if week.Allocated > week.Capacity {
+				color = overallocatedColor
+			}
+
+			row.Bars = append(row.Bars, utilizationBar{
+				X:          x,
+				Y:          baseline - height,
+				Width:      width,
+				Height:     height,
+				Color:      color,
+				Allocated:  week.Allocated,
+				Capacity:   week.Capacity,
+				LabelX:     x + width/2,
+				LabelY:     baseline - height - 4,
+				CapacityY:  baseline - plotHeight*float64(week.Capacity)/float64(scale),
+				CapacityX2: x + width,
+			})
+		}
+
+		rows = append(rows, row)
+	}
+
+	data := utilizationData{
+		Name:       project.Name,
+		Width:      220 + chartWidth + 20,
+		Height:     headerHeight + len(rows)*utilizationRowPx + 20,
+		WeekWidth:  utilizationWeekPx,
+		RowHeight:  utilizationRowPx,
+		ChartWidth: chartWidth,
+		Weeks:      weeks,
+		Rows:       rows,
+	}
+
+	// Status line, only when the status date falls inside the charted weeks
+	firstWeek := loads[0].Weeks[0].Start
+	if !opts.StatusDate.IsZero() && !opts.StatusDate.Before(firstWeek) && opts.StatusDate.Before(firstWeek.AddDate(0, 0, 7*numWeeks)) {
+		days := opts.StatusDate.Sub(firstWeek).Hours() / 24
+		data.ShowStatusLine = true
+		data.StatusX = 220 + days/7*utilizationWeekPx
+		data.StatusLineEnd = data.Height - 20
+		data.StatusLabel = opts.StatusDate.Format("Jan 2")
+	}
+
+	tmpl, err := template.New("utilization").Parse(utilizationSVGTemplate)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+// RenderUtilizationHTML wraps the utilization histogram in a standalone HTML page;
+// opts apply as for RenderUtilizationSVG
+func RenderUtilizationHTML(project *model.Project, opts Options) (string, error) {
+	svg, err := RenderUtilizationSVG(project, opts)
+	if err != nil {
+		return "", err
+	}
+
+	tmpl, err := template.New("utilizationHTML").Parse(utilizationHTMLTemplate)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, struct {
+		Name string
+		SVG  string
+	}{project.Name, svg}); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
@@ -0,0 +1,145 @@
+package renderer
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"gantt-gen/model"
+)
+
+func utilizationProject() *model.Project {
+	monday := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	nextMonday := time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC)
+	thursday := time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC)
+
+	return &model.Project{
+		Name: "Test Project",
+		Tasks: []model.Task{
+			{Name: "Task A", Level: 2, CalculatedStart: &monday, CalculatedEnd: &nextMonday, Resources: []string{"Alice"}},
+			{Name: "Task B", Level: 2, CalculatedStart: &monday, CalculatedEnd: &thursday, Resources: []string{"Alice", "Bob"}},
+			{Name: "Task C", Level: 2, CalculatedStart: &monday, CalculatedEnd: &monday},
+		},
+	}
+}
+
+func TestComputeUtilization(t *testing.T) {
+	loads, err := computeUtilization(utilizationProject())
+	if err != nil {
+		t.Fatalf("computeUtilization() error = %v", err)
+	}
+
+	if len(loads) != 2 {
+		t.Fatalf("len(loads) = %d, want 2", len(loads))
+	}
+
+	tests := []struct {
+		resource  string
+		allocated []int
+	}{
+		{"Alice", []int{8, 5}}, // Both tasks overlap in the first week
+		{"Bob", []int{4, 4}},
+	}
+
+	for i, tt := range tests {
+		load := loads[i]
+		if load.Resource != tt.resource {
+			t.Fatalf("loads[%d].Resource = %q, want %q", i, load.Resource, tt.resource)
+		}
+		if len(load.Weeks) != len(tt.allocated) {
+			t.Fatalf("%s has %d weeks, want %d", tt.resource, len(load.Weeks), len(tt.allocated))
+		}
+		for w, want := range tt.allocated {
+			if load.Weeks[w].Allocated != want {
+				t.Errorf("%s week %d allocated = %d, want %d", tt.resource, w, load.Weeks[w].Allocated, want)
+			}
+			if load.Weeks[w].Capacity != 5 {
+				t.Errorf("%s week %d capacity = %d, want 5", tt.resource, w, load.Weeks[w].Capacity)
+			}
+		}
+	}
+}
+
+func TestComputeUtilization_TaskCalendar(t *testing.T) {
+	monday := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	nextMonday := time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC)
+
+	// A seven-day calendar must not book more days than the default calendar offers
+	project := &model.Project{
+		Tasks: []model.Task{
+			{Name: "Task A", Level: 2, CalendarName: "Ops", CalculatedStart: &monday, CalculatedEnd: &nextMonday, Resources: []string{"Alice"}},
+		},
+		Calendars: []model.Calendar{
+			{Name: "Office", IsDefault: true, Weekends: []time.Weekday{time.Saturday, time.Sunday}},
+			{Name: "Ops"},
+		},
+	}
+
+	loads, err := computeUtilization(project)
+	if err != nil {
+		t.Fatalf("computeUtilization() error = %v", err)
+	}
+
+	for w, want := range []int{4, 1} {
+		week := loads[0].Weeks[w]
+		if week.Allocated != want || week.Capacity != 5 {
+			t.Errorf("week %d = %d/%d, want %d/5", w, week.Allocated, week.Capacity, want)
+		}
+	}
+}
+
+func TestRenderUtilizationSVG(t *testing.T) {
+	svg, err := RenderUtilizationSVG(utilizationProject(), Options{})
+	if err != nil {
+		t.Fatalf("RenderUtilizationSVG() error = %v", err)
+	}
+
+	if !strings.Contains(svg, "Alice") || !strings.Contains(svg, "Bob") {
+		t.Error("SVG should have a row per resource")
+	}
+	if !strings.Contains(svg, overallocatedColor) {
+		t.Error("SVG should mark over-allocated weeks")
+	}
+
+	html, err := RenderUtilizationHTML(utilizationProject(), Options{})
+	if err != nil {
+		t.Fatalf("RenderUtilizationHTML() error = %v", err)
+	}
+	if !strings.Contains(html, "<!DOCTYPE html>") || !strings.Contains(html, "<svg") {
+		t.Error("HTML should embed the histogram SVG in a page")
+	}
+}
+
+func TestRenderUtilizationSVG_StatusDate(t *testing.T) {
+	opts := Options{StatusDate: time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC)}
+	svg, err := RenderUtilizationSVG(utilizationProject(), opts)
+	if err != nil {
+		t.Fatalf("RenderUtilizationSVG() error = %v", err)
+	}
+	// The second week starts one week width after the first
+	if !strings.Contains(svg, `x1="310"`) || !strings.Contains(svg, "Jan 8") {
+		t.Error("SVG should draw the status line at the start of the second week")
+	}
+
+	svg, err = RenderUtilizationSVG(utilizationProject(), Options{StatusDate: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)})
+	if err != nil {
+		t.Fatalf("RenderUtilizationSVG() error = %v", err)
+	}
+	if strings.Contains(svg, "stroke-dasharray=\"6,4\"") {
+		t.Error("SVG should not draw a status line after the last week")
+	}
+}
+
+func TestRenderUtilizationSVG_NoAssignees(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	project := &model.Project{
+		Tasks: []model.Task{
+			{Name: "Task A", CalculatedStart: &start, CalculatedEnd: &start},
+		},
+	}
+
+	if _, err := RenderUtilizationSVG(project, Options{}); err == nil {
+		t.Error("RenderUtilizationSVG() expected error without assignees")
+	}
+}
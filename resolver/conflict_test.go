@@ -0,0 +1,155 @@
+package resolver
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"gantt-gen/model"
+)
+
+func TestResolve_ConflictFinishToFinish(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// Build starts when Design ends (Jan 5) and so finishes Jan 10, before Docs (Jan 15)
+	project := &model.Project{
+		Tasks: []model.Task{
+			{Name: "Design", Level: 2, Start: &start, Duration: 4},
+			{Name: "Docs", Level: 2, Start: &start, Duration: 10},
+			{
+				Name:     "Build",
+				Level:    2,
+				Duration: 3,
+				Dependencies: []model.Dependency{
+					{TaskName: "Design", Type: model.FinishToStart},
+					{TaskName: "Docs", Type: model.FinishToFinish},
+				},
+			},
+		},
+	}
+
+	if err := Resolve(project); err != nil {
+		t.Fatalf("Resolve() error: %v", err)
+	}
+
+	if len(project.Conflicts) != 1 {
+		t.Fatalf("Conflicts = %v, want 1 conflict", project.Conflicts)
+	}
+	conflict := project.Conflicts[0]
+	if strings.Join(conflict.Tasks, ",") != "Build,Docs" {
+		t.Errorf("Conflict tasks = %v, want [Build Docs]", conflict.Tasks)
+	}
+	if !strings.Contains(conflict.Message, "finish-to-finish on \"Docs\"") {
+		t.Errorf("Conflict message = %q, want it to name the finish-to-finish constraint", conflict.Message)
+	}
+
+	// Start constraint still wins
+	wantEnd := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	if !project.Tasks[2].CalculatedEnd.Equal(wantEnd) {
+		t.Errorf("Build end = %v, want %v", project.Tasks[2].CalculatedEnd, wantEnd)
+	}
+}
+
+func TestResolve_NoConflictFinishingAfterFinishToFinish(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// Docs finishes Jan 3; Build may not finish before it, and finishing Jan 10 is later
+	project := &model.Project{
+		Tasks: []model.Task{
+			{Name: "Design", Level: 2, Start: &start, Duration: 4},
+			{Name: "Docs", Level: 2, Start: &start, Duration: 2},
+			{
+				Name:     "Build",
+				Level:    2,
+				Duration: 3,
+				Dependencies: []model.Dependency{
+					{TaskName: "Design", Type: model.FinishToStart},
+					{TaskName: "Docs", Type: model.FinishToFinish},
+				},
+			},
+		},
+	}
+
+	if err := Resolve(project); err != nil {
+		t.Fatalf("Resolve() error: %v", err)
+	}
+	if len(project.Conflicts) != 0 {
+		t.Errorf("Conflicts = %v, want none", project.Conflicts)
+	}
+
+	wantEnd := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	if !project.Tasks[2].CalculatedEnd.Equal(wantEnd) {
+		t.Errorf("Build end = %v, want %v", project.Tasks[2].CalculatedEnd, wantEnd)
+	}
+}
+
+func TestResolve_ConflictExplicitEnd(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		task model.Task
+	}{
+		{
+			name: "start and duration disagree with end",
+			task: model.Task{Name: "Build", Level: 2, Start: &start, End: &end, Duration: 5},
+		},
+		{
+			name: "dependency pushes finish past end",
+			task: model.Task{
+				Name:     "Build",
+				Level:    2,
+				End:      &end,
+				Duration: 2,
+				Dependencies: []model.Dependency{
+					{TaskName: "Design", Type: model.FinishToStart},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			project := &model.Project{
+				Tasks: []model.Task{
+					{Name: "Design", Level: 2, Start: &start, Duration: 3},
+					tt.task,
+				},
+			}
+
+			if err := Resolve(project); err != nil {
+				t.Fatalf("Resolve() error: %v", err)
+			}
+			if len(project.Conflicts) != 1 || project.Conflicts[0].Tasks[0] != "Build" {
+				t.Errorf("Conflicts = %v, want one conflict on Build", project.Conflicts)
+			}
+		})
+	}
+}
+
+func TestResolve_NoConflictWhenConstraintsAgree(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	project := &model.Project{
+		Tasks: []model.Task{
+			{Name: "Design", Level: 2, Start: &start, Duration: 5},
+			{
+				Name:     "Review",
+				Level:    2,
+				Duration: 5,
+				Dependencies: []model.Dependency{
+					{TaskName: "Design", Type: model.StartToStart},
+					{TaskName: "Design", Type: model.FinishToFinish},
+				},
+			},
+		},
+	}
+
+	if err := Resolve(project); err != nil {
+		t.Fatalf("Resolve() error: %v", err)
+	}
+	if len(project.Conflicts) != 0 {
+		t.Errorf("Conflicts = %v, want none", project.Conflicts)
+	}
+}
@@ -0,0 +1,184 @@
+package resolver
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"gantt-gen/model"
+)
+
+func TestResolve_NotBefore(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	notBefore := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	early := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		notBefore time.Time
+		wantStart time.Time
+	}{
+		{"constraint later than dependency", notBefore, notBefore},
+		{"dependency later than constraint", early, time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			project := &model.Project{
+				Tasks: []model.Task{
+					{Name: "Design", Level: 2, Start: &start, Duration: 3},
+					{
+						Name:      "Build",
+						Level:     2,
+						Duration:  2,
+						NotBefore: &tt.notBefore,
+						Dependencies: []model.Dependency{
+							{TaskName: "Design", Type: model.FinishToStart},
+						},
+					},
+				},
+			}
+
+			if err := Resolve(project); err != nil {
+				t.Fatalf("Resolve() error: %v", err)
+			}
+			if got := project.Tasks[1].CalculatedStart; !got.Equal(tt.wantStart) {
+				t.Errorf("Build start = %v, want %v", got, tt.wantStart)
+			}
+		})
+	}
+}
+
+func TestResolve_NotBeforeWithoutDependencies(t *testing.T) {
+	notBefore := time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC)
+
+	project := &model.Project{
+		Tasks: []model.Task{
+			{Name: "Install", Level: 2, Duration: 2, NotBefore: &notBefore},
+		},
+	}
+
+	if err := Resolve(project); err != nil {
+		t.Fatalf("Resolve() error: %v", err)
+	}
+
+	wantEnd := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	if !project.Tasks[0].CalculatedStart.Equal(notBefore) || !project.Tasks[0].CalculatedEnd.Equal(wantEnd) {
+		t.Errorf("Install = %v - %v, want %v - %v",
+			project.Tasks[0].CalculatedStart, project.Tasks[0].CalculatedEnd, notBefore, wantEnd)
+	}
+}
+
+func TestResolve_MustFinishOn(t *testing.T) {
+	mustFinish := time.Date(2024, 1, 12, 0, 0, 0, 0, time.UTC)
+
+	// Without a start constraint the task is scheduled backwards from its finish
+	project := &model.Project{
+		Tasks: []model.Task{
+			{Name: "Handover", Level: 2, Duration: 3, MustFinishOn: &mustFinish},
+		},
+	}
+
+	if err := Resolve(project); err != nil {
+		t.Fatalf("Resolve() error: %v", err)
+	}
+
+	wantStart := time.Date(2024, 1, 9, 0, 0, 0, 0, time.UTC)
+	if !project.Tasks[0].CalculatedStart.Equal(wantStart) || !project.Tasks[0].CalculatedEnd.Equal(mustFinish) {
+		t.Errorf("Handover = %v - %v, want %v - %v",
+			project.Tasks[0].CalculatedStart, project.Tasks[0].CalculatedEnd, wantStart, mustFinish)
+	}
+	if len(project.Conflicts) != 0 {
+		t.Errorf("Conflicts = %v, want none", project.Conflicts)
+	}
+}
+
+func TestResolve_MustFinishOnConflict(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	mustFinish := time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)
+
+	project := &model.Project{
+		Tasks: []model.Task{
+			{Name: "Design", Level: 2, Start: &start, Duration: 5},
+			{
+				Name:         "Handover",
+				Level:        2,
+				Duration:     3,
+				MustFinishOn: &mustFinish,
+				Dependencies: []model.Dependency{
+					{TaskName: "Design", Type: model.FinishToStart},
+				},
+			},
+			{Name: "Kickoff", Level: 2, Start: &start, Duration: 1, MustFinishOn: &mustFinish},
+		},
+	}
+
+	if err := Resolve(project); err != nil {
+		t.Fatalf("Resolve() error: %v", err)
+	}
+
+	if len(project.Conflicts) != 2 {
+		t.Fatalf("Conflicts = %v, want 2", project.Conflicts)
+	}
+	if project.Conflicts[0].Tasks[0] != "Handover" || project.Conflicts[1].Tasks[0] != "Kickoff" {
+		t.Errorf("Conflicts = %v, want Handover then Kickoff", project.Conflicts)
+	}
+}
+
+func TestResolve_ConstraintOnSummaryIgnored(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	notBefore := time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC)
+
+	project := &model.Project{
+		Tasks: []model.Task{
+			{Name: "Phase", Level: 2, NotBefore: &notBefore},
+			{Name: "Child", Level: 3, Start: &start, Duration: 2},
+		},
+	}
+
+	if err := Resolve(project); err != nil {
+		t.Fatalf("Resolve() error: %v", err)
+	}
+
+	// The summary still spans its children
+	if !project.Tasks[0].CalculatedStart.Equal(start) {
+		t.Errorf("Phase start = %v, want %v", project.Tasks[0].CalculatedStart, start)
+	}
+
+	if len(project.Warnings) != 1 || project.Warnings[0].Code != model.CodeIgnoredConstraint {
+		t.Fatalf("Warnings = %v, want one ignored-constraint warning", project.Warnings)
+	}
+	if !strings.Contains(project.Warnings[0].Message, "Not Before 2024-01-08") {
+		t.Errorf("warning = %q, want it to name the ignored constraint", project.Warnings[0].Message)
+	}
+}
+
+func TestResolve_DeadlineMiss(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	deadline := time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)
+
+	project := &model.Project{
+		Tasks: []model.Task{
+			{Name: "On time", Level: 2, Start: &start, Duration: 4, Deadline: &deadline},
+			{Name: "Late", Level: 2, Start: &start, Duration: 6, Deadline: &deadline},
+		},
+	}
+
+	if err := Resolve(project); err != nil {
+		t.Fatalf("Resolve() error: %v", err)
+	}
+
+	// Deadlines never move tasks
+	wantEnd := time.Date(2024, 1, 9, 0, 0, 0, 0, time.UTC)
+	if !project.Tasks[1].CalculatedEnd.Equal(wantEnd) {
+		t.Errorf("Late end = %v, want %v", project.Tasks[1].CalculatedEnd, wantEnd)
+	}
+
+	if project.Tasks[0].MissesDeadline() || !project.Tasks[1].MissesDeadline() {
+		t.Errorf("MissesDeadline() = %v, %v, want false, true",
+			project.Tasks[0].MissesDeadline(), project.Tasks[1].MissesDeadline())
+	}
+	if len(project.Warnings) != 1 || project.Warnings[0].Task != "Late" {
+		t.Errorf("Warnings = %v, want one deadline warning for Late", project.Warnings)
+	}
+}
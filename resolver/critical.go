@@ -0,0 +1,125 @@
+package resolver
+
+import (
+	"time"
+
+	"gantt-gen/calendar"
+	"gantt-gen/model"
+)
+
+// successor is a task that depends on another task
+type successor struct {
+	task *model.Task
+	dep  model.Dependency
+}
+
+type criticalPathAnalysis struct {
+	projectEnd time.Time
+	successors map[string][]successor
+	project    *model.Project
+	done       map[string]bool
+}
+
+// analyzeCriticalPath runs the backward pass over resolved tasks, filling late
+// dates and float, and marks tasks without float as critical
+func analyzeCriticalPath(project *model.Project) error {
+	a := &criticalPathAnalysis{
+		successors: make(map[string][]successor),
+		project:    project,
+		done:       make(map[string]bool),
+	}
+
+	// Project finish is the latest calculated end
+	for _, task := range project.Tasks {
+		if task.CalculatedEnd != nil && task.CalculatedEnd.After(a.projectEnd) {
+			a.projectEnd = *task.CalculatedEnd
+		}
+	}
+	if a.projectEnd.IsZero() {
+		return nil
+	}
+
+	// Invert dependencies so each task knows what waits on it
+	for i := range project.Tasks {
+		task := &project.Tasks[i]
+		for _, dep := range task.Dependencies {
+			a.successors[dep.TaskName] = append(a.successors[dep.TaskName], successor{task: task, dep: dep})
+		}
+	}
+
+	for i := range project.Tasks {
+		if err := a.computeFloat(&project.Tasks[i], make(map[string]bool)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (a *criticalPathAnalysis) computeFloat(task *model.Task, visiting map[string]bool) error {
+	if a.done[task.Name] {
+		return nil
+	}
+	if task.CalculatedStart == nil || task.CalculatedEnd == nil {
+		return nil
+	}
+
+	if visiting[task.Name] {
+		return model.Errorf(model.CodeCircularDependency, task.Pos, task.Name, "circular dependency detected involving task: %s", task.Name)
+	}
+	visiting[task.Name] = true
+	defer delete(visiting, task.Name)
+
+	cal := a.project.CalendarFor(task)
+
+	// Without successors a task may slip until the project finishes
+	totalFloat := calendar.BusinessDaysBetween(*task.CalculatedEnd, a.projectEnd, cal)
+	freeFloat := totalFloat
+
+	for _, succ := range a.successors[task.Name] {
+		if err := a.computeFloat(succ.task, visiting); err != nil {
+			return err
+		}
+		if succ.task.LateStart == nil || succ.task.LateEnd == nil {
+			continue
+		}
+
+		// Pick the anchors the dependency type links together
+		var from, early, late time.Time
+		switch succ.dep.Type {
+		case model.StartToStart:
+			from, early, late = *task.CalculatedStart, *succ.task.CalculatedStart, *succ.task.LateStart
+		case model.FinishToFinish:
+			from, early, late = *task.CalculatedEnd, *succ.task.CalculatedEnd, *succ.task.LateEnd
+		case model.StartToFinish:
+			from, early, late = *task.CalculatedStart, *succ.task.CalculatedEnd, *succ.task.LateEnd
+		default:
+			from, early, late = *task.CalculatedEnd, *succ.task.CalculatedStart, *succ.task.LateStart
+		}
+
+		// Lag is applied on the successor's calendar, as in the forward pass
+		from = calendar.ShiftBusinessDays(from, succ.dep.Lag, a.project.CalendarFor(succ.task))
+
+		if total := calendar.BusinessDaysBetween(from, late, cal); total < totalFloat {
+			totalFloat = total
+		}
+		if free := calendar.BusinessDaysBetween(from, early, cal); free < freeFloat {
+			freeFloat = free
+		}
+	}
+
+	if freeFloat > totalFloat {
+		freeFloat = totalFloat
+	}
+
+	lateStart := calendar.ShiftBusinessDays(*task.CalculatedStart, totalFloat, cal)
+	lateEnd := calendar.ShiftBusinessDays(*task.CalculatedEnd, totalFloat, cal)
+	task.LateStart = &lateStart
+	task.LateEnd = &lateEnd
+	task.TotalFloat = totalFloat
+	task.FreeFloat = freeFloat
+	task.IsCritical = totalFloat <= 0
+
+	a.done[task.Name] = true
+	return nil
+}
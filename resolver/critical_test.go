@@ -0,0 +1,127 @@
+package resolver
+
+import (
+	"testing"
+	"time"
+
+	"gantt-gen/model"
+)
+
+func TestResolve_CriticalPath(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	project := &model.Project{
+		Tasks: []model.Task{
+			{Name: "Design", Start: &start, Duration: 5},
+			{
+				Name:     "Backend",
+				Duration: 10,
+				Dependencies: []model.Dependency{
+					{TaskName: "Design", Type: model.FinishToStart},
+				},
+			},
+			{
+				Name:     "Frontend",
+				Duration: 4,
+				Dependencies: []model.Dependency{
+					{TaskName: "Design", Type: model.FinishToStart},
+				},
+			},
+			{
+				Name:     "Testing",
+				Duration: 3,
+				Dependencies: []model.Dependency{
+					{TaskName: "Backend", Type: model.FinishToStart},
+					{TaskName: "Frontend", Type: model.FinishToStart},
+				},
+			},
+		},
+		Calendars: []model.Calendar{
+			{
+				Name:      "no-weekends",
+				IsDefault: true,
+				Weekends:  []time.Weekday{}, // No weekends
+			},
+		},
+	}
+
+	if err := Resolve(project); err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		critical   bool
+		totalFloat int
+		freeFloat  int
+	}{
+		{"Design", true, 0, 0},
+		{"Backend", true, 0, 0},
+		{"Frontend", false, 6, 6},
+		{"Testing", true, 0, 0},
+	}
+
+	for i, tt := range tests {
+		task := project.Tasks[i]
+		if task.Name != tt.name {
+			t.Fatalf("task[%d] = %q, want %q", i, task.Name, tt.name)
+		}
+		if task.IsCritical != tt.critical {
+			t.Errorf("%s IsCritical = %v, want %v", tt.name, task.IsCritical, tt.critical)
+		}
+		if task.TotalFloat != tt.totalFloat {
+			t.Errorf("%s TotalFloat = %d, want %d", tt.name, task.TotalFloat, tt.totalFloat)
+		}
+		if task.FreeFloat != tt.freeFloat {
+			t.Errorf("%s FreeFloat = %d, want %d", tt.name, task.FreeFloat, tt.freeFloat)
+		}
+	}
+
+	// Frontend (Jan 6 -> Jan 10) can slip until Backend finishes on Jan 16
+	frontend := project.Tasks[2]
+	wantLateStart := time.Date(2024, 1, 12, 0, 0, 0, 0, time.UTC)
+	wantLateEnd := time.Date(2024, 1, 16, 0, 0, 0, 0, time.UTC)
+	if frontend.LateStart == nil || !frontend.LateStart.Equal(wantLateStart) {
+		t.Errorf("Frontend LateStart = %v, want %v", frontend.LateStart, wantLateStart)
+	}
+	if frontend.LateEnd == nil || !frontend.LateEnd.Equal(wantLateEnd) {
+		t.Errorf("Frontend LateEnd = %v, want %v", frontend.LateEnd, wantLateEnd)
+	}
+}
+
+func TestResolve_CriticalPathStartToStart(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	project := &model.Project{
+		Tasks: []model.Task{
+			{Name: "Task A", Start: &start, Duration: 2},
+			{
+				Name:     "Task B",
+				Duration: 10,
+				Dependencies: []model.Dependency{
+					{TaskName: "Task A", Type: model.StartToStart},
+				},
+			},
+		},
+		Calendars: []model.Calendar{
+			{
+				Name:      "no-weekends",
+				IsDefault: true,
+				Weekends:  []time.Weekday{},
+			},
+		},
+	}
+
+	if err := Resolve(project); err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+
+	// A's start drives B, so A cannot slip even though it finishes early
+	taskA := project.Tasks[0]
+	if !taskA.IsCritical {
+		t.Errorf("Task A should be critical, total float = %d", taskA.TotalFloat)
+	}
+	if taskA.FreeFloat != 0 {
+		t.Errorf("Task A FreeFloat = %d, want 0", taskA.FreeFloat)
+	}
+}
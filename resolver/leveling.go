@@ -0,0 +1,102 @@
+package resolver
+
+import (
+	"fmt"
+	"sort"
+
+	"gantt-gen/calendar"
+	"gantt-gen/model"
+)
+
+// maxLevelingPasses bounds leveling; every pass delays a task, so this only trips on pathological plans
+const maxLevelingPasses = 10000
+
+// levelResources delays tasks until no resource works on two tasks on the same
+// business day. Higher Priority tasks keep their dates; ties go to document order.
+func (s *scheduler) levelResources(project *model.Project) error {
+	order := make([]int, len(project.Tasks))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return project.Tasks[order[a]].Priority > project.Tasks[order[b]].Priority
+	})
+
+	for pass := 0; pass < maxLevelingPasses; pass++ {
+		if !s.delayFirstOverallocation(project, order) {
+			return nil
+		}
+
+		// Reschedule everything so successors follow the delayed task
+		for i := range project.Tasks {
+			project.Tasks[i].CalculatedStart = nil
+			project.Tasks[i].CalculatedEnd = nil
+		}
+		if err := s.resolveAll(project); err != nil {
+			return err
+		}
+	}
+
+	return fmt.Errorf("resource leveling did not settle after %d passes", maxLevelingPasses)
+}
+
+// delayFirstOverallocation finds the first double booking in priority order and
+// delays the lower-priority task until the other finishes. It reports whether
+// any task was delayed.
+func (s *scheduler) delayFirstOverallocation(project *model.Project, order []int) bool {
+	booked := make(map[string][]*model.Task)
+
+	for _, i := range order {
+		task := &project.Tasks[i]
+		if task.CalculatedStart == nil || task.CalculatedEnd == nil || task.IsSummary {
+			continue
+		}
+
+		for _, resource := range task.Resources {
+			for _, other := range booked[resource] {
+				if !s.overlaps(task, other) {
+					continue
+				}
+
+				// Fixed-date tasks cannot move, so push the other one if possible
+				if s.isMovable(task) {
+					s.delay(task, other)
+					return true
+				}
+				if s.isMovable(other) {
+					s.delay(other, task)
+					return true
+				}
+			}
+			booked[resource] = append(booked[resource], task)
+		}
+	}
+
+	return false
+}
+
+// overlaps reports whether two tasks share a working day on the first task's calendar
+func (s *scheduler) overlaps(a, b *model.Task) bool {
+	start := *a.CalculatedStart
+	if b.CalculatedStart.After(start) {
+		start = *b.CalculatedStart
+	}
+	end := *a.CalculatedEnd
+	if b.CalculatedEnd.Before(end) {
+		end = *b.CalculatedEnd
+	}
+
+	return calendar.BusinessDaysBetween(start, end, s.project.CalendarFor(a)) > 0
+}
+
+// isMovable reports whether leveling may change a task's dates
+func (s *scheduler) isMovable(task *model.Task) bool {
+	return task.Start == nil && task.Date == nil && (len(task.Dependencies) > 0 || task.NotBefore != nil)
+}
+
+// delay makes task start no earlier than blocker's finish
+func (s *scheduler) delay(task, blocker *model.Task) {
+	if current, ok := s.delays[task.Name]; !ok || blocker.CalculatedEnd.After(current) {
+		s.delays[task.Name] = *blocker.CalculatedEnd
+	}
+}
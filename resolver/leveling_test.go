@@ -0,0 +1,111 @@
+package resolver
+
+import (
+	"testing"
+	"time"
+
+	"gantt-gen/model"
+)
+
+func levelingProject(backendPriority, frontendPriority int) *model.Project {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	return &model.Project{
+		Tasks: []model.Task{
+			{Name: "Design", Start: &start, Duration: 2},
+			{
+				Name:      "Backend",
+				Duration:  3,
+				Resources: []string{"Alice"},
+				Priority:  backendPriority,
+				Dependencies: []model.Dependency{
+					{TaskName: "Design", Type: model.FinishToStart},
+				},
+			},
+			{
+				Name:      "Frontend",
+				Duration:  2,
+				Resources: []string{"Alice", "Bob"},
+				Priority:  frontendPriority,
+				Dependencies: []model.Dependency{
+					{TaskName: "Design", Type: model.FinishToStart},
+				},
+			},
+			{
+				Name:     "Testing",
+				Duration: 1,
+				Dependencies: []model.Dependency{
+					{TaskName: "Frontend", Type: model.FinishToStart},
+				},
+			},
+		},
+		Calendars: []model.Calendar{
+			{
+				Name:      "no-weekends",
+				IsDefault: true,
+				Weekends:  []time.Weekday{},
+			},
+		},
+	}
+}
+
+func TestResolve_LevelResources(t *testing.T) {
+	project := levelingProject(0, 0)
+
+	if err := ResolveWithOptions(project, Options{LevelResources: true}); err != nil {
+		t.Fatalf("ResolveWithOptions() error = %v", err)
+	}
+
+	// Backend comes first in the document, so Frontend waits for it (Jan 6)
+	tests := []struct {
+		index     int
+		wantStart time.Time
+		wantEnd   time.Time
+	}{
+		{1, time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC)},
+		{2, time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC)},
+		{3, time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 9, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		task := project.Tasks[tt.index]
+		if !task.CalculatedStart.Equal(tt.wantStart) || !task.CalculatedEnd.Equal(tt.wantEnd) {
+			t.Errorf("%s = %v -> %v, want %v -> %v", task.Name,
+				task.CalculatedStart, task.CalculatedEnd, tt.wantStart, tt.wantEnd)
+		}
+	}
+}
+
+func TestResolve_LevelResourcesPriority(t *testing.T) {
+	project := levelingProject(0, 10)
+
+	if err := ResolveWithOptions(project, Options{LevelResources: true}); err != nil {
+		t.Fatalf("ResolveWithOptions() error = %v", err)
+	}
+
+	// Frontend has the higher priority, so Backend waits for it (Jan 5)
+	backend := project.Tasks[1]
+	wantStart := time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)
+	if !backend.CalculatedStart.Equal(wantStart) {
+		t.Errorf("Backend start = %v, want %v", backend.CalculatedStart, wantStart)
+	}
+
+	frontend := project.Tasks[2]
+	wantStart = time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)
+	if !frontend.CalculatedStart.Equal(wantStart) {
+		t.Errorf("Frontend start = %v, want %v", frontend.CalculatedStart, wantStart)
+	}
+}
+
+func TestResolve_WithoutLeveling(t *testing.T) {
+	project := levelingProject(0, 0)
+
+	if err := Resolve(project); err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+
+	// Without leveling Alice is double-booked from Jan 3
+	if !project.Tasks[1].CalculatedStart.Equal(*project.Tasks[2].CalculatedStart) {
+		t.Errorf("Backend and Frontend should start together without leveling")
+	}
+}
@@ -1,6 +1,7 @@
 package resolver
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
@@ -8,59 +9,115 @@ import (
 	"gantt-gen/model"
 )
 
+// Options controls optional scheduling passes
+type Options struct {
+	// LevelResources delays tasks so no resource is booked on two tasks on the same business day
+	LevelResources bool
+}
+
+// scheduler holds the lookup tables shared by the scheduling passes
+type scheduler struct {
+	project   *model.Project
+	taskMap   map[string]*model.Task
+	children  map[string][]*model.Task // Headings nested directly under each task
+	delays    map[string]time.Time     // Earliest start imposed by resource leveling
+	failed    map[string]bool          // Tasks that could not be scheduled this pass
+	conflicts []model.Conflict
+	warnings  []model.Warning
+}
+
+// errUpstream is returned for tasks that depend on a task that already failed,
+// so each problem is reported once
+var errUpstream = errors.New("depends on a task that could not be scheduled")
+
 // Resolve calculates all task dates based on dependencies and calendars
 func Resolve(project *model.Project) error {
-	// Build task map for lookup
-	taskMap := make(map[string]*model.Task)
-	for i := range project.Tasks {
-		taskMap[project.Tasks[i].Name] = &project.Tasks[i]
+	return ResolveWithOptions(project, Options{})
+}
+
+// ResolveWithOptions calculates all task dates, running the optional passes enabled in opts
+func ResolveWithOptions(project *model.Project, opts Options) error {
+	s := &scheduler{
+		project:  project,
+		taskMap:  make(map[string]*model.Task),
+		children: make(map[string][]*model.Task),
+		delays:   make(map[string]time.Time),
 	}
 
-	// Get default calendar
-	var defaultCal *model.Calendar
-	for i := range project.Calendars {
-		if project.Calendars[i].IsDefault {
-			defaultCal = &project.Calendars[i]
-			break
+	// Build task map and heading hierarchy for lookup
+	for i := range project.Tasks {
+		s.taskMap[project.Tasks[i].Name] = &project.Tasks[i]
+		for _, child := range project.ChildIndices(i) {
+			s.children[project.Tasks[i].Name] = append(s.children[project.Tasks[i].Name], &project.Tasks[child])
 		}
 	}
 
-	// Build calendar map
-	calMap := make(map[string]*model.Calendar)
-	for i := range project.Calendars {
-		calMap[project.Calendars[i].Name] = &project.Calendars[i]
+	err := s.resolveAll(project)
+	if err == nil && opts.LevelResources {
+		err = s.levelResources(project)
+	}
+
+	// Warnings about the tasks that were scheduled are kept even when others failed
+	project.Warnings = append(project.Warnings, s.warnings...)
+	s.checkParentSpans(project)
+	s.checkDeadlines(project)
+	if err != nil {
+		return err
 	}
+	project.Conflicts = append(project.Conflicts, s.conflicts...)
 
-	// Resolve each task (topological order handled by recursive resolution)
+	// Backward pass for float and critical path
+	return analyzeCriticalPath(project)
+}
+
+// resolveAll resolves each task (topological order handled by recursive resolution).
+// It carries on past tasks that cannot be scheduled and returns all of their
+// problems as model.Diagnostics.
+func (s *scheduler) resolveAll(project *model.Project) error {
+	s.conflicts = nil
+	s.warnings = nil
+	s.failed = make(map[string]bool)
+
+	var diags model.Diagnostics
 	for i := range project.Tasks {
-		if err := resolveTask(&project.Tasks[i], taskMap, calMap, defaultCal, make(map[string]bool)); err != nil {
+		err := s.resolveTask(&project.Tasks[i], make(map[string]bool))
+		var diag model.Diagnostic
+		switch {
+		case err == nil, errors.Is(err, errUpstream):
+		case errors.As(err, &diag):
+			diags = append(diags, diag)
+		default:
 			return err
 		}
 	}
-
-	return nil
+	return diags.Err()
 }
 
-func resolveTask(task *model.Task, taskMap map[string]*model.Task, calMap map[string]*model.Calendar, defaultCal *model.Calendar, visiting map[string]bool) error {
+func (s *scheduler) resolveTask(task *model.Task, visiting map[string]bool) (err error) {
 	// Already resolved?
 	if task.CalculatedStart != nil && task.CalculatedEnd != nil {
 		return nil
 	}
 
+	// A failure also fails everything scheduled from this task
+	if s.failed[task.Name] {
+		return errUpstream
+	}
+	defer func() {
+		if err != nil {
+			s.failed[task.Name] = true
+		}
+	}()
+
 	// Cycle detection
 	if visiting[task.Name] {
-		return fmt.Errorf("circular dependency detected involving task: %s", task.Name)
+		return model.Errorf(model.CodeCircularDependency, task.Pos, task.Name, "circular dependency detected involving task: %s", task.Name)
 	}
 	visiting[task.Name] = true
 	defer delete(visiting, task.Name)
 
 	// Get calendar
-	cal := defaultCal
-	if task.CalendarName != "" {
-		if c, ok := calMap[task.CalendarName]; ok {
-			cal = c
-		}
-	}
+	cal := s.project.CalendarFor(task)
 
 	// Case 1: Explicit start date
 	if task.Start != nil {
@@ -70,12 +127,17 @@ func resolveTask(task *model.Task, taskMap map[string]*model.Task, calMap map[st
 		if task.Duration > 0 {
 			end := calendar.AddBusinessDays(start, task.Duration, cal)
 			task.CalculatedEnd = &end
+			if task.End != nil && !task.End.Equal(end) {
+				s.conflict([]string{task.Name}, "task %q finishes %s from Start + Duration but its End is %s",
+					task.Name, formatDate(end), formatDate(*task.End))
+			}
 		} else if task.End != nil {
 			task.CalculatedEnd = task.End
 		} else {
 			// Milestone with explicit date
 			task.CalculatedEnd = &start
 		}
+		s.checkFixedConstraints(task)
 		return nil
 	}
 
@@ -89,85 +151,152 @@ func resolveTask(task *model.Task, taskMap map[string]*model.Task, calMap map[st
 	if task.Date != nil {
 		task.CalculatedStart = task.Date
 		task.CalculatedEnd = task.Date
+		s.checkFixedConstraints(task)
 		return nil
 	}
 
-	// Case 4: Calculate from dependencies
-	if len(task.Dependencies) > 0 {
+	// Case 4: Calculate from dependencies and scheduling constraints
+	hasConstraints := task.NotBefore != nil || task.MustFinishOn != nil
+	if len(task.Dependencies) > 0 || (hasConstraints && len(s.children[task.Name]) == 0) {
 		var startConstraint time.Time
 		var endConstraint time.Time
 		hasStartConstraint := false
 		hasEndConstraint := false
+		endSource := "" // What imposes the end constraint, for conflict reports
+		var endTasks []string
+		exactEnd := false // Explicit End and Must Finish On fix the finish; dependencies only bound it from below
 
 		for _, dep := range task.Dependencies {
-			depTask, ok := taskMap[dep.TaskName]
+			depTask, ok := s.taskMap[dep.TaskName]
 			if !ok {
-				return fmt.Errorf("dependency not found: %s", dep.TaskName)
+				return model.Errorf(model.CodeUnknownDependency, dep.Pos, task.Name, "dependency not found: %s", dep.TaskName)
 			}
 
 			// Resolve dependency first
-			if err := resolveTask(depTask, taskMap, calMap, defaultCal, visiting); err != nil {
+			if err := s.resolveTask(depTask, visiting); err != nil {
 				return err
 			}
 
 			switch dep.Type {
 			case model.FinishToStart:
-				// Task starts when dependency finishes
+				// Task starts when dependency finishes (plus lag)
 				if depTask.CalculatedEnd != nil {
-					if !hasStartConstraint || depTask.CalculatedEnd.After(startConstraint) {
-						startConstraint = *depTask.CalculatedEnd
+					constraint := calendar.ShiftBusinessDays(*depTask.CalculatedEnd, dep.Lag, cal)
+					if !hasStartConstraint || constraint.After(startConstraint) {
+						startConstraint = constraint
 						hasStartConstraint = true
 					}
 				}
 
 			case model.StartToStart:
-				// Task starts when dependency starts
+				// Task starts when dependency starts (plus lag)
 				if depTask.CalculatedStart != nil {
-					if !hasStartConstraint || depTask.CalculatedStart.After(startConstraint) {
-						startConstraint = *depTask.CalculatedStart
+					constraint := calendar.ShiftBusinessDays(*depTask.CalculatedStart, dep.Lag, cal)
+					if !hasStartConstraint || constraint.After(startConstraint) {
+						startConstraint = constraint
 						hasStartConstraint = true
 					}
 				}
 
 			case model.FinishToFinish:
-				// Task finishes when dependency finishes
+				// Task finishes when dependency finishes (plus lag)
 				if depTask.CalculatedEnd != nil {
-					if !hasEndConstraint || depTask.CalculatedEnd.After(endConstraint) {
-						endConstraint = *depTask.CalculatedEnd
+					constraint := calendar.ShiftBusinessDays(*depTask.CalculatedEnd, dep.Lag, cal)
+					if !hasEndConstraint || constraint.After(endConstraint) {
+						endConstraint = constraint
 						hasEndConstraint = true
+						endSource = fmt.Sprintf("%s on %q", dep.Type, dep.TaskName)
+						endTasks = []string{dep.TaskName}
+						exactEnd = false
 					}
 				}
 
 			case model.StartToFinish:
-				// Task finishes when dependency starts
+				// Task finishes when dependency starts (plus lag)
 				if depTask.CalculatedStart != nil {
-					if !hasEndConstraint || depTask.CalculatedStart.After(endConstraint) {
-						endConstraint = *depTask.CalculatedStart
+					constraint := calendar.ShiftBusinessDays(*depTask.CalculatedStart, dep.Lag, cal)
+					if !hasEndConstraint || constraint.After(endConstraint) {
+						endConstraint = constraint
 						hasEndConstraint = true
+						endSource = fmt.Sprintf("%s on %q", dep.Type, dep.TaskName)
+						endTasks = []string{dep.TaskName}
+						exactEnd = false
 					}
 				}
 
 			default:
 				// Treat unknown types as finish-to-start
+				s.warnings = append(s.warnings, model.Warning{
+					Task: task.Name,
+					Code: model.CodeUnknownDependencyType,
+					Message: fmt.Sprintf("task %q: unknown dependency type %q on %q; treated as %s",
+						task.Name, dep.Type, dep.TaskName, model.FinishToStart),
+					Pos: dep.Pos,
+				})
 				if depTask.CalculatedEnd != nil {
-					if !hasStartConstraint || depTask.CalculatedEnd.After(startConstraint) {
-						startConstraint = *depTask.CalculatedEnd
+					constraint := calendar.ShiftBusinessDays(*depTask.CalculatedEnd, dep.Lag, cal)
+					if !hasStartConstraint || constraint.After(startConstraint) {
+						startConstraint = constraint
 						hasStartConstraint = true
 					}
 				}
 			}
 		}
 
+		// An explicit End is a finish constraint too
+		if task.End != nil && (!hasEndConstraint || task.End.After(endConstraint)) {
+			endConstraint = *task.End
+			hasEndConstraint = true
+			endSource = "explicit End"
+			endTasks = nil
+			exactEnd = true
+		}
+
+		// Start No Earlier Than combines with dependency start constraints
+		if task.NotBefore != nil && (!hasStartConstraint || task.NotBefore.After(startConstraint)) {
+			startConstraint = *task.NotBefore
+			hasStartConstraint = true
+		}
+
+		// Must Finish On overrides any other finish constraint
+		if task.MustFinishOn != nil {
+			endConstraint = *task.MustFinishOn
+			hasEndConstraint = true
+			endSource = "Must Finish On"
+			endTasks = nil
+			exactEnd = true
+		}
+
+		// Leveling delays act as an extra start constraint
+		if delay, ok := s.delays[task.Name]; ok && (!hasStartConstraint || delay.After(startConstraint)) {
+			startConstraint = delay
+			hasStartConstraint = true
+		}
+
 		// Resolve based on constraint types
 		if hasStartConstraint && hasEndConstraint {
-			// Both constraints: use start constraint, calculate end from duration
-			// (This is a simplification; real MS Project would check for conflicts)
+			// Both constraints: use start constraint, calculate end from duration,
+			// and report a conflict if that misses the required finish. A finish
+			// set by a dependency is only a lower bound, so finishing later is fine.
 			task.CalculatedStart = &startConstraint
 			if task.Duration > 0 {
 				end := calendar.AddBusinessDays(startConstraint, task.Duration, cal)
 				task.CalculatedEnd = &end
+				if end.Before(endConstraint) || (exactEnd && end.After(endConstraint)) {
+					s.conflict(append([]string{task.Name}, endTasks...),
+						"task %q finishes %s from its start and duration but %s requires %s",
+						task.Name, formatDate(end), endSource, formatDate(endConstraint))
+				}
+			} else if !exactEnd && endConstraint.Before(startConstraint) {
+				// Finishing as it starts already satisfies a dependency's finish bound
+				task.CalculatedEnd = &startConstraint
 			} else {
 				task.CalculatedEnd = &endConstraint
+				if endConstraint.Before(startConstraint) {
+					s.conflict(append([]string{task.Name}, endTasks...),
+						"task %q must start by %s but %s requires it to finish on %s",
+						task.Name, formatDate(startConstraint), endSource, formatDate(endConstraint))
+				}
 			}
 		} else if hasStartConstraint {
 			// Only start constraint: calculate normally
@@ -182,18 +311,175 @@ func resolveTask(task *model.Task, taskMap map[string]*model.Task, calMap map[st
 			// Only end constraint: calculate backwards from end
 			task.CalculatedEnd = &endConstraint
 			if task.Duration > 0 {
-				// Calculate start by subtracting duration (rough approximation)
-				start := endConstraint.AddDate(0, 0, -task.Duration)
+				start := calendar.SubtractBusinessDays(endConstraint, task.Duration, cal)
 				task.CalculatedStart = &start
 			} else {
 				task.CalculatedStart = &endConstraint
 			}
 		} else {
-			return fmt.Errorf("task %s has dependencies but none could be resolved", task.Name)
+			return model.Errorf(model.CodeUnresolvedDependencies, task.Pos, task.Name, "task %s has dependencies but none could be resolved", task.Name)
+		}
+
+		return nil
+	}
+
+	// Case 5: Summary heading spans its children
+	if children := s.children[task.Name]; len(children) > 0 {
+		for _, child := range children {
+			if err := s.resolveTask(child, visiting); err != nil {
+				return err
+			}
 		}
 
+		start, end := span(children)
+		task.CalculatedStart = &start
+		task.CalculatedEnd = &end
+		task.IsSummary = true
+		s.warnIgnoredConstraints(task)
 		return nil
 	}
 
-	return fmt.Errorf("task %s has no start date, date range, or dependencies", task.Name)
+	return model.Errorf(model.CodeNoTiming, task.Pos, task.Name, "task %s has no start date, date range, or dependencies", task.Name)
+}
+
+// checkFixedConstraints reports constraints broken by a task's explicit dates
+func (s *scheduler) checkFixedConstraints(task *model.Task) {
+	if task.NotBefore != nil && task.CalculatedStart.Before(*task.NotBefore) {
+		s.conflict([]string{task.Name}, "task %q starts %s but must not start before %s",
+			task.Name, formatDate(*task.CalculatedStart), formatDate(*task.NotBefore))
+	}
+	if task.MustFinishOn != nil && !task.CalculatedEnd.Equal(*task.MustFinishOn) {
+		s.conflict([]string{task.Name}, "task %q finishes %s but Must Finish On requires %s",
+			task.Name, formatDate(*task.CalculatedEnd), formatDate(*task.MustFinishOn))
+	}
+}
+
+// warnIgnoredConstraints warns about scheduling constraints on a summary task,
+// whose dates roll up from its subtasks instead
+func (s *scheduler) warnIgnoredConstraints(task *model.Task) {
+	constraints := []struct {
+		key  string
+		date *time.Time
+	}{
+		{"Not Before", task.NotBefore},
+		{"Must Finish On", task.MustFinishOn},
+	}
+
+	for _, c := range constraints {
+		if c.date == nil {
+			continue
+		}
+		s.warnings = append(s.warnings, model.Warning{
+			Task: task.Name,
+			Code: model.CodeIgnoredConstraint,
+			Message: fmt.Sprintf("task %q: %s %s is ignored on a summary task; constrain its subtasks instead",
+				task.Name, c.key, formatDate(*c.date)),
+			Pos: task.PropertyPosition(c.key),
+		})
+	}
+}
+
+// conflict records a constraint that could not be satisfied, located at the
+// conflicting task (the first of tasks)
+func (s *scheduler) conflict(tasks []string, format string, args ...interface{}) {
+	var pos model.Position
+	if task, ok := s.taskMap[tasks[0]]; ok {
+		pos = task.Pos
+	}
+	s.conflicts = append(s.conflicts, model.Conflict{
+		Tasks:   tasks,
+		Message: fmt.Sprintf(format, args...),
+		Pos:     pos,
+	})
+}
+
+func formatDate(t time.Time) string {
+	return t.Format("2006-01-02")
+}
+
+// span returns the earliest start and latest end of resolved tasks
+func span(tasks []*model.Task) (time.Time, time.Time) {
+	var start, end time.Time
+	for _, task := range tasks {
+		if start.IsZero() || task.CalculatedStart.Before(start) {
+			start = *task.CalculatedStart
+		}
+		if end.IsZero() || task.CalculatedEnd.After(end) {
+			end = *task.CalculatedEnd
+		}
+	}
+	return start, end
+}
+
+// resolved reports whether every task has calculated dates
+func resolved(tasks ...*model.Task) bool {
+	for _, task := range tasks {
+		if task.CalculatedStart == nil || task.CalculatedEnd == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// checkParentSpans warns when a parent heading's own timing does not line up
+// with the span of its children
+func (s *scheduler) checkParentSpans(project *model.Project) {
+	for i := range project.Tasks {
+		task := &project.Tasks[i]
+		children := s.children[task.Name]
+		if len(children) == 0 || !resolved(task) || !resolved(children...) {
+			continue
+		}
+
+		start, end := span(children)
+
+		// A rolled-up summary only disagrees if it also states a duration
+		if task.IsSummary {
+			if task.Duration == 0 {
+				continue
+			}
+			cal := s.project.CalendarFor(task)
+			if spanned := calendar.BusinessDaysBetween(start, end, cal); spanned != task.Duration {
+				project.Warnings = append(project.Warnings, model.Warning{
+					Task:    task.Name,
+					Code:    model.CodeSummaryMismatch,
+					Message: fmt.Sprintf("task %q has duration %dd but its subtasks span %dd", task.Name, task.Duration, spanned),
+					Pos:     task.PropertyPosition("Duration"),
+				})
+			}
+			continue
+		}
+
+		if task.CalculatedStart.Equal(start) && task.CalculatedEnd.Equal(end) {
+			continue
+		}
+
+		project.Warnings = append(project.Warnings, model.Warning{
+			Task: task.Name,
+			Code: model.CodeSummaryMismatch,
+			Message: fmt.Sprintf("task %q is scheduled %s - %s but its subtasks span %s - %s",
+				task.Name,
+				task.CalculatedStart.Format("2006-01-02"), task.CalculatedEnd.Format("2006-01-02"),
+				start.Format("2006-01-02"), end.Format("2006-01-02")),
+			Pos: task.Pos,
+		})
+	}
+}
+
+// checkDeadlines warns about tasks scheduled to finish after their deadline
+func (s *scheduler) checkDeadlines(project *model.Project) {
+	for i := range project.Tasks {
+		task := &project.Tasks[i]
+		if !task.MissesDeadline() {
+			continue
+		}
+		late := calendar.BusinessDaysBetween(*task.Deadline, *task.CalculatedEnd, s.project.CalendarFor(task))
+		project.Warnings = append(project.Warnings, model.Warning{
+			Task: task.Name,
+			Code: model.CodeMissedDeadline,
+			Message: fmt.Sprintf("task %q finishes %s, after its deadline %s (%d business days late)",
+				task.Name, formatDate(*task.CalculatedEnd), formatDate(*task.Deadline), late),
+			Pos: task.PropertyPosition("Deadline"),
+		})
+	}
 }
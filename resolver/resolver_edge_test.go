@@ -1,6 +1,7 @@
 package resolver
 
 import (
+	"errors"
 	"testing"
 	"time"
 
@@ -36,6 +37,92 @@ func TestResolve_TaskWithNoDatesOrDependencies(t *testing.T) {
 	}
 }
 
+func TestResolve_ReportsEveryUnschedulableTask(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	project := &model.Project{
+		Tasks: []model.Task{
+			{Name: "Task A", Duration: 5},
+			{Name: "Task B", Start: &start, Duration: 2},
+			{Name: "Task C", Duration: 3},
+			// Downstream of a failed task: not reported again
+			{Name: "Task D", Duration: 1, Dependencies: []model.Dependency{{TaskName: "Task A", Type: model.FinishToStart}}},
+			// Cycle reported once
+			{Name: "Task E", Duration: 1, Dependencies: []model.Dependency{{TaskName: "Task F", Type: model.FinishToStart}}},
+			{Name: "Task F", Duration: 1, Dependencies: []model.Dependency{{TaskName: "Task E", Type: model.FinishToStart}}},
+		},
+	}
+
+	var diags model.Diagnostics
+	if err := Resolve(project); !errors.As(err, &diags) {
+		t.Fatalf("Resolve() error = %v, want Diagnostics", err)
+	}
+
+	want := []string{model.CodeNoTiming, model.CodeNoTiming, model.CodeCircularDependency}
+	if len(diags) != len(want) {
+		t.Fatalf("Resolve() = %d diagnostics, want %d: %v", len(diags), len(want), diags)
+	}
+	for i, code := range want {
+		if diags[i].Code != code {
+			t.Errorf("diagnostic[%d].Code = %q, want %q", i, diags[i].Code, code)
+		}
+	}
+
+	if project.Tasks[1].CalculatedEnd == nil {
+		t.Error("schedulable tasks should still be resolved")
+	}
+}
+
+func TestResolve_KeepsWarningsWhenSchedulingFails(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	project := &model.Project{
+		Tasks: []model.Task{
+			{Name: "Phase", Level: 2, Duration: 3},
+			{Name: "Child", Level: 3, Start: &start, Duration: 5},
+			{Name: "Follow-up", Level: 2, Duration: 1, Dependencies: []model.Dependency{
+				{TaskName: "Child", Type: "finish-to-begin"},
+			}},
+			{Name: "Broken", Level: 2, Duration: 2},
+		},
+	}
+
+	var diags model.Diagnostics
+	if err := Resolve(project); !errors.As(err, &diags) || len(diags) != 1 {
+		t.Fatalf("Resolve() error = %v, want one diagnostic", err)
+	}
+
+	codes := make(map[string]bool)
+	for _, warning := range project.Warnings {
+		codes[warning.Code] = true
+	}
+	if !codes[model.CodeSummaryMismatch] || !codes[model.CodeUnknownDependencyType] {
+		t.Errorf("warnings = %+v, want summary mismatch and unknown dependency type", project.Warnings)
+	}
+}
+
+func TestResolve_UnknownDependencyType(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	project := &model.Project{
+		Tasks: []model.Task{
+			{Name: "Task A", Start: &start, Duration: 5},
+			{Name: "Task B", Duration: 2, Dependencies: []model.Dependency{
+				{TaskName: "Task A", Type: "finish-to-begin", Pos: model.Position{Line: 9, Column: 3}},
+			}},
+		},
+	}
+
+	if err := Resolve(project); err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+
+	// Scheduled as finish-to-start, with a warning at the dependency row
+	if !project.Tasks[1].CalculatedStart.Equal(*project.Tasks[0].CalculatedEnd) {
+		t.Errorf("Task B start = %v, want %v", project.Tasks[1].CalculatedStart, project.Tasks[0].CalculatedEnd)
+	}
+	if len(project.Warnings) != 1 || project.Warnings[0].Code != model.CodeUnknownDependencyType || project.Warnings[0].Pos.Line != 9 {
+		t.Errorf("warnings = %+v, want one unknown dependency type on line 9", project.Warnings)
+	}
+}
+
 func TestResolve_MilestoneWithDate(t *testing.T) {
 	date := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
 
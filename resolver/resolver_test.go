@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"gantt-gen/calendar"
 	"gantt-gen/model"
 )
 
@@ -206,3 +207,110 @@ func TestResolve_StartToFinish(t *testing.T) {
 		t.Errorf("Task B start = %v, want %v", taskB.CalculatedStart, wantStart)
 	}
 }
+
+func TestResolve_DependencyLag(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC) // Monday
+
+	tests := []struct {
+		name      string
+		dep       model.Dependency
+		wantStart time.Time
+		wantEnd   time.Time
+	}{
+		{
+			name:      "finish-to-start with lag skips weekend",
+			dep:       model.Dependency{TaskName: "Task A", Type: model.FinishToStart, Lag: 3},
+			wantStart: time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC), // A ends Mon Jan 8, +3 business days
+			wantEnd:   time.Date(2024, 1, 16, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "finish-to-start with lead time",
+			dep:       model.Dependency{TaskName: "Task A", Type: model.FinishToStart, Lag: -2},
+			wantStart: time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC), // Mon Jan 8 back over the weekend
+			wantEnd:   time.Date(2024, 1, 9, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "start-to-start with lag",
+			dep:       model.Dependency{TaskName: "Task A", Type: model.StartToStart, Lag: 5},
+			wantStart: time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "finish-to-finish with lag",
+			dep:       model.Dependency{TaskName: "Task A", Type: model.FinishToFinish, Lag: 1},
+			wantStart: time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC), // 3 business days back over the weekend
+			wantEnd:   time.Date(2024, 1, 9, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "start-to-finish with lag",
+			dep:       model.Dependency{TaskName: "Task A", Type: model.StartToFinish, Lag: 5},
+			wantStart: time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			project := &model.Project{
+				Tasks: []model.Task{
+					{Name: "Task A", Start: &start, Duration: 5}, // Jan 1 -> Mon Jan 8
+					{Name: "Task B", Duration: 3, Dependencies: []model.Dependency{tt.dep}},
+				},
+			}
+
+			if err := Resolve(project); err != nil {
+				t.Fatalf("Resolve() error = %v", err)
+			}
+
+			taskB := project.Tasks[1]
+			if taskB.CalculatedStart == nil || !taskB.CalculatedStart.Equal(tt.wantStart) {
+				t.Errorf("Task B start = %v, want %v", taskB.CalculatedStart, tt.wantStart)
+			}
+			if taskB.CalculatedEnd == nil || !taskB.CalculatedEnd.Equal(tt.wantEnd) {
+				t.Errorf("Task B end = %v, want %v", taskB.CalculatedEnd, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestResolve_FinishToFinishSkipsWeekendsAndHolidays(t *testing.T) {
+	start := time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC) // Monday
+
+	project := &model.Project{
+		Tasks: []model.Task{
+			{Name: "Task A", Start: &start, Duration: 5}, // -> Tue Jan 16
+			{
+				Name:     "Task B",
+				Duration: 5,
+				Dependencies: []model.Dependency{
+					{TaskName: "Task A", Type: model.FinishToFinish},
+				},
+			},
+		},
+		Calendars: []model.Calendar{
+			{
+				Name:      "us",
+				IsDefault: true,
+				Weekends:  []time.Weekday{time.Saturday, time.Sunday},
+				Holidays:  []time.Time{time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)},
+			},
+		},
+	}
+
+	if err := Resolve(project); err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+
+	// Working back from Tue Jan 16: 16, 15, 12, 11, 9 (skipping the weekend and the Jan 10 holiday)
+	taskB := project.Tasks[1]
+	wantStart := time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC)
+	if !taskB.CalculatedStart.Equal(wantStart) {
+		t.Errorf("Task B start = %v, want %v", taskB.CalculatedStart, wantStart)
+	}
+
+	// Scheduling forward from the computed start reproduces the end
+	cal := &project.Calendars[0]
+	if end := calendar.AddBusinessDays(*taskB.CalculatedStart, taskB.Duration, cal); !end.Equal(*taskB.CalculatedEnd) {
+		t.Errorf("forward end = %v, want %v", end, taskB.CalculatedEnd)
+	}
+}
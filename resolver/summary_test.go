@@ -0,0 +1,126 @@
+package resolver
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"gantt-gen/model"
+)
+
+func TestResolve_SummaryRollUp(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	project := &model.Project{
+		Tasks: []model.Task{
+			{Name: "Design", Level: 2, Start: &start, Duration: 5},
+			{Name: "Implementation", Level: 2},
+			{
+				Name:     "Backend",
+				Level:    3,
+				Duration: 10,
+				Dependencies: []model.Dependency{
+					{TaskName: "Design", Type: model.FinishToStart},
+				},
+			},
+			{
+				Name:     "Frontend",
+				Level:    3,
+				Duration: 4,
+				Dependencies: []model.Dependency{
+					{TaskName: "Design", Type: model.FinishToStart},
+				},
+			},
+			{
+				Name:     "Testing",
+				Level:    2,
+				Duration: 3,
+				Dependencies: []model.Dependency{
+					{TaskName: "Implementation", Type: model.FinishToStart},
+				},
+			},
+		},
+		Calendars: []model.Calendar{
+			{
+				Name:      "no-weekends",
+				IsDefault: true,
+				Weekends:  []time.Weekday{},
+			},
+		},
+	}
+
+	if err := Resolve(project); err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+
+	impl := project.Tasks[1]
+	if !impl.IsSummary {
+		t.Error("Implementation should be a summary task")
+	}
+
+	// Spans Backend (Jan 6 -> Jan 16) and Frontend (Jan 6 -> Jan 10)
+	wantStart := time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC)
+	wantEnd := time.Date(2024, 1, 16, 0, 0, 0, 0, time.UTC)
+	if impl.CalculatedStart == nil || !impl.CalculatedStart.Equal(wantStart) {
+		t.Errorf("Implementation start = %v, want %v", impl.CalculatedStart, wantStart)
+	}
+	if impl.CalculatedEnd == nil || !impl.CalculatedEnd.Equal(wantEnd) {
+		t.Errorf("Implementation end = %v, want %v", impl.CalculatedEnd, wantEnd)
+	}
+
+	// Successors of the summary follow its rolled-up finish
+	successor := project.Tasks[4]
+	if !successor.CalculatedStart.Equal(wantEnd) {
+		t.Errorf("Testing start = %v, want %v", successor.CalculatedStart, wantEnd)
+	}
+
+	if len(project.Warnings) != 0 {
+		t.Errorf("unexpected warnings: %v", project.Warnings)
+	}
+}
+
+func TestResolve_ParentDurationDisagreesWithChildren(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		parent model.Task
+		want   string
+	}{
+		{
+			name:   "explicit dates",
+			parent: model.Task{Name: "Phase", Level: 2, Start: &start, Duration: 3},
+			want:   `task "Phase" is scheduled 2024-01-01 - 2024-01-04 but its subtasks span 2024-01-01 - 2024-01-06`,
+		},
+		{
+			name:   "rolled-up duration",
+			parent: model.Task{Name: "Phase", Level: 2, Duration: 3},
+			want:   `task "Phase" has duration 3d but its subtasks span 5d`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			project := &model.Project{
+				Tasks: []model.Task{
+					tt.parent,
+					{Name: "Child", Level: 3, Start: &start, Duration: 5},
+				},
+				Calendars: []model.Calendar{
+					{Name: "no-weekends", IsDefault: true, Weekends: []time.Weekday{}},
+				},
+			}
+
+			if err := Resolve(project); err != nil {
+				t.Fatalf("Resolve() error = %v", err)
+			}
+
+			if len(project.Warnings) != 1 {
+				t.Fatalf("len(warnings) = %d, want 1", len(project.Warnings))
+			}
+			if got := project.Warnings[0].Message; !strings.Contains(got, tt.want) {
+				t.Errorf("warning = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"os"
+	"time"
+)
+
+// watchInterval is how often watched files are polled for changes
+const watchInterval = 500 * time.Millisecond
+
+// fileState is what watchFiles compares to notice a change
+type fileState struct {
+	modTime time.Time
+	size    int64
+	exists  bool
+}
+
+func statFile(path string) fileState {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileState{}
+	}
+	return fileState{modTime: info.ModTime(), size: info.Size(), exists: true}
+}
+
+// watchFiles calls onChange once, then again each time any of paths is
+// modified, created or removed, until stop is closed (or forever if stop is nil).
+// Polling keeps this portable and dependency-free; editors that save by
+// renaming a new file into place are picked up too.
+func watchFiles(paths []string, interval time.Duration, stop <-chan struct{}, onChange func()) {
+	states := make([]fileState, len(paths))
+	for i, path := range paths {
+		states[i] = statFile(path)
+	}
+	onChange()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+
+		changed := false
+		for i, path := range paths {
+			if state := statFile(path); state != states[i] {
+				states[i] = state
+				changed = true
+			}
+		}
+		if changed {
+			onChange()
+		}
+	}
+}
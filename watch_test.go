@@ -0,0 +1,48 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWatchFiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "plan.md")
+	if err := os.WriteFile(path, []byte("# Plan\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	calls := make(chan struct{}, 10)
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		watchFiles([]string{path}, 10*time.Millisecond, stop, func() { calls <- struct{}{} })
+		close(done)
+	}()
+
+	wait := func(what string) {
+		select {
+		case <-calls:
+		case <-time.After(2 * time.Second):
+			t.Fatalf("timed out waiting for %s", what)
+		}
+	}
+
+	wait("initial run")
+
+	if err := os.WriteFile(path, []byte("# Plan\n\n## Task\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	wait("run after change")
+
+	// No change, no run
+	select {
+	case <-calls:
+		t.Error("onChange called without a change")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(stop)
+	<-done
+}